@@ -28,6 +28,107 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/diff": {
+            "get": {
+                "description": "Compare the project metadata, plans, flows, cross-sections and hydraulic structures of a base and a revised RAS model given their s3 keys",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Compare two RAS models",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY REVISED/CHURCH HOUSE GULLY.prj",
+                        "name": "revised_definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tools.ModelDiff"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/footprint": {
+            "get": {
+                "description": "Extract a polygon covering the cross-sections, storage areas and 2D flow areas of a RAS model and its bounding box given an s3 key. The convex hull encloses all of their vertices; the concave footprint is the union of each reach's corridor joining the ends of its cross-section cut lines with the storage area and 2D flow area polygons, not a true concave hull.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract the model footprint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "convex (default) or concave, the union of reach corridors and storage and 2D flow areas",
+                        "name": "hull",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "EPSG code of the output coordinate reference system, defaults to the CRS configured for the API",
+                        "name": "crs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tools.Footprint"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/geospatialdata": {
             "get": {
                 "description": "Extract geospatial data from a RAS model given an s3 key",
@@ -48,6 +149,36 @@ var doc = `{
                         "name": "definition_file",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Scale cross-section stations along cut lines that do not match the profile length",
+                        "name": "scale_stations",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "error (default) or warn when the model and coordinate reference system units differ, US survey and international feet only warn",
+                        "name": "unit_check",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add approximate inundation boundaries of each plan with results, joining the wet limits of the cross-sections held by unovertopped levees; ineffective flow areas are included and the terrain between cross-sections is ignored",
+                        "name": "inundation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the profile results of each steady plan to the cross-sections",
+                        "name": "results",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -57,6 +188,91 @@ var doc = `{
                             "type": "object"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/hydrographs": {
+            "get": {
+                "description": "Extract the stage and flow time series of an unsteady plan at cross-sections, storage areas and 2D reference lines and points of a RAS model given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract unsteady hydrographs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "plan file name or extension, e.g. p01",
+                        "name": "plan",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "cross-section (River, Reach, Station), storage area or reference line or point name, defaults to all locations",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "stage or flow, defaults to both",
+                        "name": "variable",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.Hydrograph"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -86,6 +302,12 @@ var doc = `{
                         "name": "definition_file",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -95,6 +317,12 @@ var doc = `{
                             "$ref": "#/definitions/tools.Model"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -168,6 +396,71 @@ var doc = `{
                 }
             }
         },
+        "/mesh": {
+            "get": {
+                "description": "Extract the cell, face and face point counts and the cell sizes of each 2D flow area from the geometry HDF files of a RAS model given an s3 key, optionally with the cell polygons",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract 2D mesh statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return the cell polygons, defaults to false",
+                        "name": "cells",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "EPSG code of the cell polygons' coordinate reference system, defaults to 4326",
+                        "name": "crs",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.MeshStatistics"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/modeltype": {
             "get": {
                 "description": "Extract the model type given an s3 key",
@@ -230,7 +523,7 @@ var doc = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "4.0",
+                        "description": ".p01: 5.07, .g01: 5.07",
                         "schema": {
                             "type": "string"
                         }
@@ -266,29 +559,328 @@ var doc = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.SimpleResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "integer"
+        },
+        "/spatialqa": {
+            "get": {
+                "description": "Check a RAS model for crossing cross-sections, cut lines that miss or repeatedly cross the river centerline or are drawn right to left, river stations that do not increase upstream and self-intersecting storage areas given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Check the model geometry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.QAFinding"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
                 }
             }
         },
-        "tools.ControlFiles": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "description": "placeholder",
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "paths": {
+        "/stationing": {
+            "get": {
+                "description": "Compare the river station difference per unit of centerline length of adjacent cross-sections with that of their reach, which allows stationing in miles or kilometres, and return the pairs that disagree given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Check the river stationing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "allowed difference as a fraction of the reach's river station difference per unit length, defaults to 0.1",
+                        "name": "tolerance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.StationingFinding"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/structures": {
+            "get": {
+                "description": "List the culverts, bridges, inline weirs, lateral structures, gates, culvert conduits, pump stations and rating curve outlets of every geometry file in a RAS model as one table given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract a structure inventory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.StructureRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terraincomparison": {
+            "get": {
+                "description": "Sample a terrain along each cross-section cut line and summarize the differences from the station-elevation profile of a RAS model given an s3 key. Terrain elevations are converted to the model's units",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Compare cross-sections with the terrain",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "s3 key of a GeoTIFF or RAS terrain .hdf, defaults to the terrain in the RAS Mapper file",
+                        "name": "terrain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.XSTerrainComparison"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/versionreport": {
+            "get": {
+                "description": "Report the RAS version of each plan, geometry and flow file, the derived model version and whether the model mixes versions, given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Report the RAS version of each model file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tools.VersionReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/xsprofiles": {
+            "get": {
+                "description": "Extract the station-elevation points, Manning's n breaks, bank stations, ineffective flow areas and levees of every cross-section in a RAS model given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract cross-section profiles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "English or SI, defaults to the model's units",
+                        "name": "units",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tools.XSProfile"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.SimpleResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tools.ControlFiles": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "placeholder",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "paths": {
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -296,6 +888,55 @@ var doc = `{
                 }
             }
         },
+        "tools.FileVersion": {
+            "type": "object",
+            "properties": {
+                "Program Version": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "major": {
+                    "type": "integer"
+                },
+                "minor": {
+                    "type": "integer"
+                },
+                "patch": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.Footprint": {
+            "type": "object",
+            "properties": {
+                "bbox": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "geometry": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "georeference": {
+                    "type": "integer"
+                },
+                "hull": {
+                    "type": "string"
+                }
+            }
+        },
         "tools.ForcingFiles": {
             "type": "object",
             "properties": {
@@ -329,6 +970,55 @@ var doc = `{
                     "items": {
                         "type": "string"
                     }
+                },
+                "units": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.Hydrograph": {
+            "type": "object",
+            "properties": {
+                "Location Type": {
+                    "type": "string"
+                },
+                "Times": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "Values": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "variable": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.IneffectiveArea": {
+            "type": "object",
+            "properties": {
+                "End Station": {
+                    "type": "number"
+                },
+                "Start Station": {
+                    "type": "number"
+                },
+                "elevation": {
+                    "type": "number"
+                },
+                "permanent": {
+                    "type": "boolean"
                 }
             }
         },
@@ -354,9 +1044,72 @@ var doc = `{
                 }
             }
         },
+        "tools.Levee": {
+            "type": "object",
+            "properties": {
+                "elevation": {
+                    "type": "number"
+                },
+                "side": {
+                    "type": "string"
+                },
+                "station": {
+                    "type": "number"
+                }
+            }
+        },
+        "tools.ManningsBreak": {
+            "type": "object",
+            "properties": {
+                "n": {
+                    "type": "number"
+                },
+                "station": {
+                    "type": "number"
+                }
+            }
+        },
+        "tools.MeshStatistics": {
+            "type": "object",
+            "properties": {
+                "2D Flow Area": {
+                    "type": "string"
+                },
+                "Cell Count": {
+                    "type": "integer"
+                },
+                "Face Count": {
+                    "type": "integer"
+                },
+                "Face Point Count": {
+                    "type": "integer"
+                },
+                "Max Cell Size": {
+                    "type": "number"
+                },
+                "Mean Cell Size": {
+                    "type": "number"
+                },
+                "Min Cell Size": {
+                    "type": "number"
+                },
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.VectorLayer"
+                    }
+                },
+                "geomFile": {
+                    "type": "string"
+                }
+            }
+        },
         "tools.Model": {
             "type": "object",
             "properties": {
+                "Version Report": {
+                    "$ref": "#/definitions/tools.VersionReport"
+                },
                 "definitionFile": {
                     "type": "string"
                 },
@@ -371,6 +1124,49 @@ var doc = `{
                 }
             }
         },
+        "tools.ModelChange": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "object"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "change": {
+                    "type": "string"
+                },
+                "feature": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "revised": {
+                    "type": "object"
+                }
+            }
+        },
+        "tools.ModelDiff": {
+            "type": "object",
+            "properties": {
+                "Base File": {
+                    "type": "string"
+                },
+                "Revised File": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.ModelChange"
+                    }
+                }
+            }
+        },
         "tools.ModelFiles": {
             "type": "object",
             "properties": {
@@ -412,6 +1208,108 @@ var doc = `{
                 }
             }
         },
+        "tools.QAFinding": {
+            "type": "object",
+            "properties": {
+                "check": {
+                    "type": "string"
+                },
+                "feature": {
+                    "type": "string"
+                },
+                "geomFile": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.StationingFinding": {
+            "type": "object",
+            "properties": {
+                "Downstream XS": {
+                    "type": "string"
+                },
+                "Measured Distance": {
+                    "type": "number"
+                },
+                "Reach Ratio": {
+                    "type": "number"
+                },
+                "Stated Distance": {
+                    "type": "number"
+                },
+                "Upstream XS": {
+                    "type": "string"
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "geomFile": {
+                    "type": "string"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.StructureRecord": {
+            "type": "object",
+            "properties": {
+                "High Elevation": {
+                    "type": "number"
+                },
+                "Low Elevation": {
+                    "type": "number"
+                },
+                "River Station": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "geomFile": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                },
+                "shape": {
+                    "type": "string"
+                },
+                "structure": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
         "tools.SupplementalFiles": {
             "type": "object",
             "properties": {
@@ -430,6 +1328,141 @@ var doc = `{
                     "type": "object"
                 }
             }
+        },
+        "tools.VectorLayer": {
+            "type": "object",
+            "properties": {
+                "feature_name": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "geometry": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "tools.VersionReport": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.FileVersion"
+                    }
+                },
+                "mixed": {
+                    "type": "boolean"
+                },
+                "modelVersion": {
+                    "type": "string"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "tools.XSProfile": {
+            "type": "object",
+            "properties": {
+                "Bank Stations": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "Elevations": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "Ineffective Areas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.IneffectiveArea"
+                    }
+                },
+                "Levees": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.Levee"
+                    }
+                },
+                "Mannings N": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.ManningsBreak"
+                    }
+                },
+                "River Station": {
+                    "type": "string"
+                },
+                "Stations": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "geomFile": {
+                    "type": "string"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                }
+            }
+        },
+        "tools.XSTerrainComparison": {
+            "type": "object",
+            "properties": {
+                "Max Difference": {
+                    "type": "number"
+                },
+                "Max Difference Station": {
+                    "type": "number"
+                },
+                "Mean Absolute Difference": {
+                    "type": "number"
+                },
+                "Mean Difference": {
+                    "type": "number"
+                },
+                "Points Missing": {
+                    "type": "integer"
+                },
+                "Points Sampled": {
+                    "type": "integer"
+                },
+                "River Station": {
+                    "type": "string"
+                },
+                "geomFile": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                },
+                "terrain": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
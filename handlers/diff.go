@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Diff godoc
+// @Summary Compare two RAS models
+// @Description Compare the project metadata, plans, flows, cross-sections and hydraulic structures of a base and a revised RAS model given their s3 keys
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param revised_definition_file query string true "/models/ras/CHURCH HOUSE GULLY REVISED/CHURCH HOUSE GULLY.prj"
+// @Success 200 {object} ras.ModelDiff
+// @Failure 500 {object} SimpleResponse
+// @Router /diff [get]
+func Diff(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+		revisedDefinitionFile := c.QueryParam("revised_definition_file")
+
+		base, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		revised, err := ras.NewRasModel(revisedDefinitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		diff, err := ras.DiffModels(base, revised)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, diff)
+	}
+}
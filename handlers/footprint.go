@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Footprint godoc
+// @Summary Extract the model footprint
+// @Description Extract a polygon covering the cross-sections, storage areas and 2D flow areas of a RAS model and its bounding box given an s3 key. The convex hull encloses all of their vertices; the concave footprint is the union of each reach's corridor joining the ends of its cross-section cut lines with the storage area and 2D flow area polygons, not a true concave hull.
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param hull query string false "convex (default) or concave, the union of reach corridors and storage and 2D flow areas"
+// @Param crs query int false "EPSG code of the output coordinate reference system, defaults to the CRS configured for the API"
+// @Success 200 {object} ras.Footprint
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /footprint [get]
+func Footprint(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		hull := c.QueryParam("hull")
+		if hull == "" {
+			hull = "convex"
+		}
+		if !ras.ValidHull(hull) {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, hull + " is not a valid hull, use convex or concave"})
+		}
+
+		destinationCRS := ac.DestinationCRS
+		if param := c.QueryParam("crs"); param != "" {
+			var err error
+			destinationCRS, err = strconv.Atoi(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		fp, err := rm.Footprint(destinationCRS, hull)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, fp)
+	}
+}
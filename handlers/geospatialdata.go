@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/USACE/mcat-ras/config"
 	ras "github.com/USACE/mcat-ras/tools"
@@ -16,7 +18,13 @@ import (
 // @Accept json
 // @Produce json
 // @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param scale_stations query bool false "Scale cross-section stations along cut lines that do not match the profile length"
+// @Param units query string false "English or SI, defaults to the model's units"
+// @Param unit_check query string false "error (default) or warn when the model and coordinate reference system units differ, US survey and international feet only warn"
+// @Param inundation query bool false "Add approximate inundation boundaries of each plan with results, joining the wet limits of the cross-sections held by unovertopped levees; ineffective flow areas are included and the terrain between cross-sections is ignored"
+// @Param results query bool false "Add the profile results of each steady plan to the cross-sections"
 // @Success 200 {object} interface{}
+// @Failure 400 {object} SimpleResponse
 // @Failure 500 {object} SimpleResponse
 // @Router /geospatialdata [get]
 func GeospatialData(ac *config.APIConfig) echo.HandlerFunc {
@@ -24,16 +32,67 @@ func GeospatialData(ac *config.APIConfig) echo.HandlerFunc {
 
 		definitionFile := c.QueryParam("definition_file")
 
+		scaleStations := false
+		if param := c.QueryParam("scale_stations"); param != "" {
+			var err error
+			scaleStations, err = strconv.ParseBool(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		inundation := false
+		if param := c.QueryParam("inundation"); param != "" {
+			var err error
+			inundation, err = strconv.ParseBool(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		results := false
+		if param := c.QueryParam("results"); param != "" {
+			var err error
+			results, err = strconv.ParseBool(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		unitCheck := c.QueryParam("unit_check")
+		if unitCheck == "" {
+			unitCheck = "error"
+		}
+		if unitCheck != "error" && unitCheck != "warn" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, fmt.Sprintf("%s is not a valid unit check, use error or warn", unitCheck)})
+		}
+
 		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
 		}
 
-		data, err := rm.GeospatialData(ac.DestinationCRS)
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
+		data, err := rm.GeospatialData(ac.DestinationCRS, scaleStations, unitCheck == "warn")
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
 		}
 
+		if results {
+			if err := rm.SteadyResults(&data); err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+			}
+		}
+
+		if inundation {
+			if err := rm.InundationBoundaries(&data, ac.DestinationCRS); err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+			}
+		}
+
 		return c.JSON(http.StatusOK, data)
 	}
 }
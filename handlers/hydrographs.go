@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Hydrographs godoc
+// @Summary Extract unsteady hydrographs
+// @Description Extract the stage and flow time series of an unsteady plan at cross-sections, storage areas and 2D reference lines and points of a RAS model given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param plan query string true "plan file name or extension, e.g. p01"
+// @Param location query string false "cross-section (River, Reach, Station), storage area or reference line or point name, defaults to all locations"
+// @Param variable query string false "stage or flow, defaults to both"
+// @Param format query string false "json (default) or csv"
+// @Param units query string false "English or SI, defaults to the model's units"
+// @Success 200 {array} ras.Hydrograph
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /hydrographs [get]
+func Hydrographs(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		plan := c.QueryParam("plan")
+		if plan == "" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, "a plan is required"})
+		}
+
+		format := c.QueryParam("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "json" && format != "csv" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, fmt.Sprintf("%s is not a valid format, use json or csv", format)})
+		}
+
+		variable := c.QueryParam("variable")
+		if variable != "" && variable != "stage" && variable != "flow" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, fmt.Sprintf("%s is not a valid variable, use stage or flow", variable)})
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
+		hydrographs, err := rm.Hydrographs(plan, c.QueryParam("location"), variable)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if format == "csv" {
+			var buf bytes.Buffer
+			if err := ras.WriteHydrographsCSV(&buf, hydrographs); err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+			}
+			return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+		}
+
+		return c.JSON(http.StatusOK, hydrographs)
+	}
+}
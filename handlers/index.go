@@ -16,7 +16,9 @@ import (
 // @Accept json
 // @Produce json
 // @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param units query string false "English or SI, defaults to the model's units"
 // @Success 200 {object} ras.Model
+// @Failure 400 {object} SimpleResponse
 // @Failure 500 {object} SimpleResponse
 // @Router /index [get]
 func Index(fs *filestore.FileStore) echo.HandlerFunc {
@@ -28,6 +30,11 @@ func Index(fs *filestore.FileStore) echo.HandlerFunc {
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
 		}
+
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
 		mod := rm.Index()
 
 		return c.JSON(http.StatusOK, mod)
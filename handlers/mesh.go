@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Mesh godoc
+// @Summary Extract 2D mesh statistics
+// @Description Extract the cell, face and face point counts and the cell sizes of each 2D flow area from the geometry HDF files of a RAS model given an s3 key, optionally with the cell polygons
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param cells query bool false "return the cell polygons, defaults to false"
+// @Param crs query int false "EPSG code of the cell polygons' coordinate reference system, defaults to 4326"
+// @Param units query string false "English or SI, defaults to the model's units"
+// @Success 200 {array} ras.MeshStatistics
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /mesh [get]
+func Mesh(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		cells := false
+		if param := c.QueryParam("cells"); param != "" {
+			var err error
+			cells, err = strconv.ParseBool(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		destinationCRS := ac.DestinationCRS
+		if param := c.QueryParam("crs"); param != "" {
+			var err error
+			destinationCRS, err = strconv.Atoi(param)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
+		statistics, err := rm.Mesh(destinationCRS, cells)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, statistics)
+	}
+}
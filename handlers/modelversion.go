@@ -16,7 +16,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
-// @Success 200 {string} string "4.0"
+// @Success 200 {string} string ".p01: 5.07, .g01: 5.07"
 // @Failure 500 {object} SimpleResponse
 // @Router /modelversion [get]
 func ModelVersion(fs *filestore.FileStore) echo.HandlerFunc {
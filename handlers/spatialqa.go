@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SpatialQA godoc
+// @Summary Check the model geometry
+// @Description Check a RAS model for crossing cross-sections, cut lines that miss or repeatedly cross the river centerline or are drawn right to left, river stations that do not increase upstream and self-intersecting storage areas given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Success 200 {array} ras.QAFinding
+// @Failure 500 {object} SimpleResponse
+// @Router /spatialqa [get]
+func SpatialQA(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		findings, err := rm.SpatialQA(ac.DestinationCRS)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, findings)
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Stationing godoc
+// @Summary Check the river stationing
+// @Description Compare the river station difference per unit of centerline length of adjacent cross-sections with that of their reach, which allows stationing in miles or kilometres, and return the pairs that disagree given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param tolerance query number false "allowed difference as a fraction of the reach's river station difference per unit length, defaults to 0.1"
+// @Success 200 {array} ras.StationingFinding
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /stationing [get]
+func Stationing(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		tolerance := ras.StationingTolerance
+		if param := c.QueryParam("tolerance"); param != "" {
+			var err error
+			tolerance, err = strconv.ParseFloat(param, 64)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+			}
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		findings, err := rm.StationingReport(tolerance)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, findings)
+	}
+}
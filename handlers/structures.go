@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Structures godoc
+// @Summary Extract a structure inventory
+// @Description List the culverts, bridges, inline weirs, lateral structures, gates, culvert conduits, pump stations and rating curve outlets of every geometry file in a RAS model as one table given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param format query string false "json (default) or csv"
+// @Param units query string false "English or SI, defaults to the model's units"
+// @Success 200 {array} ras.StructureRecord
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /structures [get]
+func Structures(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		format := c.QueryParam("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "json" && format != "csv" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, fmt.Sprintf("%s is not a valid format, use json or csv", format)})
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
+		records := rm.StructureInventory()
+
+		if format == "csv" {
+			var buf bytes.Buffer
+			if err := ras.WriteStructuresCSV(&buf, records); err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+			}
+			return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+		}
+
+		return c.JSON(http.StatusOK, records)
+	}
+}
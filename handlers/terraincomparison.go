@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TerrainComparison godoc
+// @Summary Compare cross-sections with the terrain
+// @Description Sample a terrain along each cross-section cut line and summarize the differences from the station-elevation profile of a RAS model given an s3 key. Terrain elevations are converted to the model's units
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param terrain query string false "s3 key of a GeoTIFF or RAS terrain .hdf, defaults to the terrain in the RAS Mapper file"
+// @Success 200 {array} ras.XSTerrainComparison
+// @Failure 500 {object} SimpleResponse
+// @Router /terraincomparison [get]
+func TerrainComparison(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+		terrain := c.QueryParam("terrain")
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		comparisons, err := rm.TerrainComparison(terrain)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		return c.JSON(http.StatusOK, comparisons)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/USACE/filestore"
+	"github.com/labstack/echo/v4"
+)
+
+// VersionReport godoc
+// @Summary Report the RAS version of each model file
+// @Description Report the RAS version of each plan, geometry and flow file, the derived model version and whether the model mixes versions, given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Success 200 {object} ras.VersionReport
+// @Failure 500 {object} SimpleResponse
+// @Router /versionreport [get]
+func VersionReport(fs *filestore.FileStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		rm, err := ras.NewRasModel(definitionFile, *fs)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+		report := rm.ModelVersionReport()
+
+		return c.JSON(http.StatusOK, report)
+	}
+}
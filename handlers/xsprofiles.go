@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/USACE/mcat-ras/config"
+	ras "github.com/USACE/mcat-ras/tools"
+
+	"github.com/labstack/echo/v4"
+)
+
+// XSProfiles godoc
+// @Summary Extract cross-section profiles
+// @Description Extract the station-elevation points, Manning's n breaks, bank stations, ineffective flow areas and levees of every cross-section in a RAS model given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param format query string false "json (default) or csv"
+// @Param units query string false "English or SI, defaults to the model's units"
+// @Success 200 {array} ras.XSProfile
+// @Failure 400 {object} SimpleResponse
+// @Failure 500 {object} SimpleResponse
+// @Router /xsprofiles [get]
+func XSProfiles(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+
+		format := c.QueryParam("format")
+		if format == "" {
+			format = "json"
+		}
+		if format != "json" && format != "csv" {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, fmt.Sprintf("%s is not a valid format, use json or csv", format)})
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *ac.FileStore)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if err := rm.ConvertUnits(c.QueryParam("units")); err != nil {
+			return c.JSON(http.StatusBadRequest, SimpleResponse{http.StatusBadRequest, err.Error()})
+		}
+
+		profiles, err := rm.XSProfiles()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+		}
+
+		if format == "csv" {
+			var buf bytes.Buffer
+			if err := ras.WriteXSProfilesCSV(&buf, profiles); err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, err.Error()})
+			}
+			return c.Blob(http.StatusOK, "text/csv", buf.Bytes())
+		}
+
+		return c.JSON(http.StatusOK, profiles)
+	}
+}
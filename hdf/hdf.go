@@ -0,0 +1,155 @@
+// Package hdf reads datasets from HDF files with gdal's multidimensional API. The API reads the compound and string
+// datasets that the HDF5 raster driver does not list as subdatasets, such as the attribute tables of a HEC-RAS
+// geometry, and is not bound by github.com/dewberry/gdal, so this package binds the few functions it needs.
+package hdf
+
+/*
+#cgo linux  pkg-config: gdal
+#cgo darwin pkg-config: gdal
+#include <stdlib.h>
+#include "gdal.h"
+#include "cpl_error.h"
+#include "cpl_vsi.h"
+
+// openMDArray opens a dataset by its full path without reporting an error when it is not in the file
+static GDALMDArrayH openMDArray(GDALGroupH root, const char *path) {
+	CPLPushErrorHandler(CPLQuietErrorHandler);
+	GDALMDArrayH array = GDALGroupOpenMDArrayFromFullname(root, path, NULL);
+	CPLPopErrorHandler();
+	return array;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// ErrNotFound is returned when a dataset is not in an HDF file
+var ErrNotFound = errors.New("dataset not found")
+
+// File is an HDF file opened for reading
+type File struct {
+	ds   C.GDALDatasetH
+	root C.GDALGroupH
+}
+
+// Open opens an HDF file from any path gdal can read, including local files and /vsis3/ paths
+func Open(path string) (*File, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	ds := C.GDALOpenEx(cPath, C.GDAL_OF_MULTIDIM_RASTER|C.GDAL_OF_READONLY, nil, nil, nil)
+	if ds == nil {
+		return nil, fmt.Errorf("%s could not be opened: %s", path, C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	root := C.GDALDatasetGetRootGroup(ds)
+	if root == nil {
+		C.GDALClose(ds)
+		return nil, fmt.Errorf("%s has no root group", path)
+	}
+	return &File{ds: ds, root: root}, nil
+}
+
+// Close releases the file
+func (f *File) Close() {
+	C.GDALGroupRelease(f.root)
+	C.GDALClose(f.ds)
+}
+
+// openArray opens a dataset by its full path, e.g. /Geometry/2D Flow Areas/Attributes. The array must be released.
+func (f *File) openArray(path string) (C.GDALMDArrayH, error) {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+	array := C.openMDArray(f.root, cPath)
+	if array == nil {
+		return nil, fmt.Errorf("%s: %w", path, ErrNotFound)
+	}
+	return array, nil
+}
+
+// arrayShape returns the size of each dimension of an array, the start and count to read all of it and its number of
+// values. Scalar datasets are treated as empty.
+func arrayShape(array C.GDALMDArrayH) ([]int, []C.GUInt64, []C.size_t, int) {
+	var nDims C.size_t
+	dims := C.GDALMDArrayGetDimensions(array, &nDims)
+	if dims == nil || nDims == 0 {
+		return []int{}, nil, nil, 0
+	}
+	defer C.GDALReleaseDimensions(dims, nDims)
+
+	shape, start, count, n := []int{}, []C.GUInt64{}, []C.size_t{}, 1
+	for _, dim := range (*[1 << 20]C.GDALDimensionH)(unsafe.Pointer(dims))[:nDims:nDims] {
+		size := C.GDALDimensionGetSize(dim)
+		shape = append(shape, int(size))
+		start = append(start, 0)
+		count = append(count, C.size_t(size))
+		n *= int(size)
+	}
+	return shape, start, count, n
+}
+
+// ReadFloats reads a numeric dataset and returns its values in row order along with the size of each dimension
+func (f *File) ReadFloats(path string) ([]float64, []int, error) {
+	array, err := f.openArray(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer C.GDALMDArrayRelease(array)
+
+	shape, start, count, n := arrayShape(array)
+	values := make([]float64, n)
+	if n == 0 {
+		return values, shape, nil
+	}
+
+	dataType := C.GDALExtendedDataTypeCreate(C.GDT_Float64)
+	defer C.GDALExtendedDataTypeRelease(dataType)
+	if C.GDALMDArrayRead(array, &start[0], &count[0], nil, nil, dataType, unsafe.Pointer(&values[0]), nil, 0) == 0 {
+		return nil, nil, fmt.Errorf("%s could not be read: %s", path, C.GoString(C.CPLGetLastErrorMsg()))
+	}
+	return values, shape, nil
+}
+
+// ReadStrings reads a string dataset, or a string field of a compound dataset when field is set, and returns its
+// values with surrounding spaces removed
+func (f *File) ReadStrings(path string, field string) ([]string, error) {
+	array, err := f.openArray(path)
+	if err != nil {
+		return nil, err
+	}
+	defer C.GDALMDArrayRelease(array)
+
+	if field != "" {
+		cView := C.CString(fmt.Sprintf(`["%s"]`, field))
+		defer C.free(unsafe.Pointer(cView))
+		view := C.GDALMDArrayGetView(array, cView)
+		if view == nil {
+			return nil, fmt.Errorf("%s has no field %s", path, field)
+		}
+		defer C.GDALMDArrayRelease(view)
+		array = view
+	}
+
+	_, start, count, n := arrayShape(array)
+	values := make([]string, n)
+	if n == 0 {
+		return values, nil
+	}
+
+	dataType := C.GDALExtendedDataTypeCreateString(0)
+	defer C.GDALExtendedDataTypeRelease(dataType)
+	buffer := C.calloc(C.size_t(n), C.size_t(unsafe.Sizeof((*C.char)(nil))))
+	defer C.free(buffer)
+	if C.GDALMDArrayRead(array, &start[0], &count[0], nil, nil, dataType, buffer, nil, 0) == 0 {
+		return nil, fmt.Errorf("%s could not be read: %s", path, C.GoString(C.CPLGetLastErrorMsg()))
+	}
+
+	for i, s := range (*[1 << 28]*C.char)(buffer)[:n:n] {
+		values[i] = strings.TrimSpace(C.GoString(s))
+		C.VSIFree(unsafe.Pointer(s))
+	}
+	return values, nil
+}
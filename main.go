@@ -37,9 +37,19 @@ func main() {
 	e.GET("/isamodel", handlers.IsAModel(appConfig.FileStore))
 	e.GET("/modeltype", handlers.ModelType(appConfig.FileStore))
 	e.GET("/modelversion", handlers.ModelVersion(appConfig.FileStore))
+	e.GET("/versionreport", handlers.VersionReport(appConfig.FileStore))
 	e.GET("/index", handlers.Index(appConfig.FileStore))
 	e.GET("/isgeospatial", handlers.IsGeospatial(appConfig.FileStore))
 	e.GET("/geospatialdata", handlers.GeospatialData(appConfig))
+	e.GET("/footprint", handlers.Footprint(appConfig))
+	e.GET("/spatialqa", handlers.SpatialQA(appConfig))
+	e.GET("/xsprofiles", handlers.XSProfiles(appConfig))
+	e.GET("/stationing", handlers.Stationing(appConfig))
+	e.GET("/terraincomparison", handlers.TerrainComparison(appConfig))
+	e.GET("/diff", handlers.Diff(appConfig))
+	e.GET("/structures", handlers.Structures(appConfig))
+	e.GET("/mesh", handlers.Mesh(appConfig))
+	e.GET("/hydrographs", handlers.Hydrographs(appConfig))
 
 	e.Logger.Fatal(e.Start(appConfig.Address()))
 }
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/dewberry/gdal"
+)
+
+// xsBankPoints holds the end points and bank points of a cross-section, ordered left to right looking downstream
+type xsBankPoints struct {
+	station float64
+	ends    [2][2]float64
+	banks   [2][2]float64
+}
+
+// pointsFromWKB returns the vertices of a point, line or a multi-part point or line geometry
+func pointsFromWKB(wkb []uint8) ([][2]float64, error) {
+	points := [][2]float64{}
+	if len(wkb) == 0 {
+		return points, nil
+	}
+
+	geom, err := gdal.CreateFromWKB(wkb, gdal.SpatialReference{}, len(wkb))
+	if err != nil {
+		return points, err
+	}
+	defer geom.Destroy()
+
+	parts := []gdal.Geometry{geom}
+	if geom.GeometryCount() > 0 {
+		parts = []gdal.Geometry{}
+		for i := 0; i < geom.GeometryCount(); i++ {
+			parts = append(parts, geom.Geometry(i))
+		}
+	}
+	for _, part := range parts {
+		for i := 0; i < part.PointCount(); i++ {
+			x, y, _ := part.Point(i)
+			points = append(points, [2]float64{x, y})
+		}
+	}
+	return points, nil
+}
+
+func lineStringLayer(name string, fields map[string]interface{}, points [][2]float64) (VectorLayer, error) {
+	layer := VectorLayer{FeatureName: name, Fields: fields}
+
+	lineString := gdal.Create(gdal.GT_LineString)
+	for _, p := range points {
+		lineString.AddPoint2D(p[0], p[1])
+	}
+	multiLineString := lineString.ForceToMultiLineString()
+	wkb, err := multiLineString.ToWKB()
+	if err != nil {
+		return layer, err
+	}
+	layer.Geometry = wkb
+	return layer, nil
+}
+
+func polygonLayer(name string, fields map[string]interface{}, ring [][2]float64) (VectorLayer, error) {
+	layer := VectorLayer{FeatureName: name, Fields: fields}
+
+	multiPolygon := ringPolygon(ring).ForceToMultiPolygon()
+	wkb, err := multiPolygon.ToWKB()
+	if err != nil {
+		return layer, err
+	}
+	layer.Geometry = wkb
+	return layer, nil
+}
+
+func reversed(points [][2]float64) [][2]float64 {
+	r := make([][2]float64, len(points))
+	for i, p := range points {
+		r[len(points)-1-i] = p
+	}
+	return r
+}
+
+// bankRings connects the cross-sections of a reach, ordered upstream to downstream, into left and right
+// bank lines and the channel, left overbank and right overbank rings
+func bankRings(xss []xsBankPoints) ([2][][2]float64, [3][][2]float64) {
+	var leftBank, rightBank, leftEdge, rightEdge [][2]float64
+	for _, xs := range xss {
+		leftEdge = append(leftEdge, xs.ends[0])
+		leftBank = append(leftBank, xs.banks[0])
+		rightBank = append(rightBank, xs.banks[1])
+		rightEdge = append(rightEdge, xs.ends[1])
+	}
+
+	channel := append(append([][2]float64{}, leftBank...), reversed(rightBank)...)
+	leftOverbank := append(append([][2]float64{}, leftEdge...), reversed(leftBank)...)
+	rightOverbank := append(append([][2]float64{}, rightBank...), reversed(rightEdge)...)
+
+	return [2][][2]float64{leftBank, rightBank}, [3][][2]float64{channel, leftOverbank, rightOverbank}
+}
+
+// getBankLines connects the bank points of each reach in river station order into left and right bank lines,
+// and builds channel and overbank polygons between the bank lines and the cross-section end points
+func getBankLines(f *Features) error {
+	banksByXS := map[string][][2]float64{}
+	for _, bank := range f.Banks {
+		points, err := pointsFromWKB(bank.Geometry)
+		if err != nil {
+			return err
+		}
+		if len(points) == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%v|%v", bank.Fields["RiverReachName"], bank.Fields["xsName"])
+		banksByXS[key] = append(banksByXS[key], points[0])
+	}
+
+	reaches := []string{}
+	xsByReach := map[string][]xsBankPoints{}
+	for _, xs := range f.XS {
+		reach := fmt.Sprintf("%v", xs.Fields["RiverReachName"])
+		banks := banksByXS[fmt.Sprintf("%s|%s", reach, xs.FeatureName)]
+		if len(banks) != 2 {
+			continue
+		}
+
+		station, err := strconv.ParseFloat(xs.FeatureName, 64)
+		if err != nil {
+			continue
+		}
+
+		points, err := pointsFromWKB(xs.Geometry)
+		if err != nil {
+			return err
+		}
+		if len(points) < 2 {
+			continue
+		}
+
+		if _, ok := xsByReach[reach]; !ok {
+			reaches = append(reaches, reach)
+		}
+		xsByReach[reach] = append(xsByReach[reach], xsBankPoints{
+			station: station,
+			ends:    [2][2]float64{points[0], points[len(points)-1]},
+			banks:   [2][2]float64{banks[0], banks[1]},
+		})
+	}
+
+	for _, reach := range reaches {
+		xss := xsByReach[reach]
+		if len(xss) < 2 {
+			continue
+		}
+		sort.SliceStable(xss, func(i, j int) bool { return xss[i].station > xss[j].station })
+
+		bankLines, rings := bankRings(xss)
+
+		for i, side := range []string{"Left", "Right"} {
+			layer, err := lineStringLayer(fmt.Sprintf("%s, %s Bank", reach, side), map[string]interface{}{"RiverReachName": reach, "Side": side}, bankLines[i])
+			if err != nil {
+				return err
+			}
+			f.BankLines = append(f.BankLines, layer)
+		}
+
+		channel, err := polygonLayer(reach, map[string]interface{}{"RiverReachName": reach, "Side": "Channel"}, rings[0])
+		if err != nil {
+			return err
+		}
+		f.ChannelPolygons = append(f.ChannelPolygons, channel)
+
+		for i, side := range []string{"Left", "Right"} {
+			layer, err := polygonLayer(fmt.Sprintf("%s, %s Overbank", reach, side), map[string]interface{}{"RiverReachName": reach, "Side": side}, rings[i+1])
+			if err != nil {
+				return err
+			}
+			f.OverbankPolygons = append(f.OverbankPolygons, layer)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBankRings(t *testing.T) {
+	// two cross-sections 100 apart, 200 wide with banks at 50 and 150, ordered upstream to downstream
+	xss := []xsBankPoints{
+		{station: 200, ends: [2][2]float64{{0, 100}, {200, 100}}, banks: [2][2]float64{{50, 100}, {150, 100}}},
+		{station: 100, ends: [2][2]float64{{0, 0}, {200, 0}}, banks: [2][2]float64{{50, 0}, {150, 0}}},
+	}
+	bankLines, rings := bankRings(xss)
+
+	tests := []struct {
+		name string
+		got  [][2]float64
+		want [][2]float64
+	}{
+		{"left bank line", bankLines[0], [][2]float64{{50, 100}, {50, 0}}},
+		{"right bank line", bankLines[1], [][2]float64{{150, 100}, {150, 0}}},
+		{"channel", rings[0], [][2]float64{{50, 100}, {50, 0}, {150, 0}, {150, 100}}},
+		{"left overbank", rings[1], [][2]float64{{0, 100}, {0, 0}, {50, 0}, {50, 100}}},
+		{"right overbank", rings[2], [][2]float64{{150, 100}, {150, 0}, {200, 0}, {200, 100}}},
+	}
+	for _, tc := range tests {
+		if !reflect.DeepEqual(tc.got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, tc.got, tc.want)
+		}
+	}
+
+	// the channel and overbanks cover the area between the cross-sections without overlapping
+	if area := polygonArea(rings[0]) + polygonArea(rings[1]) + polygonArea(rings[2]); area != 200*100 {
+		t.Errorf("got a total area of %v, want %v", area, 200*100)
+	}
+}
+
+func TestGetBankLines(t *testing.T) {
+	f := Features{}
+	// cross-sections out of river station order, with one lacking bank points
+	for _, xs := range []struct {
+		name  string
+		y     float64
+		banks bool
+	}{{"100", 0, true}, {"300", 200, false}, {"200", 100, true}} {
+		fields := map[string]interface{}{"RiverReachName": "Creek, Main"}
+		layer, err := lineStringLayer(xs.name, fields, [][2]float64{{0, xs.y}, {200, xs.y}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.XS = append(f.XS, layer)
+		if !xs.banks {
+			continue
+		}
+		for _, x := range []float64{50, 150} {
+			bank, err := lineStringLayer(formatFloat(x), map[string]interface{}{"RiverReachName": "Creek, Main", "xsName": xs.name},
+				[][2]float64{{x, xs.y}, {x, xs.y}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.Banks = append(f.Banks, bank)
+		}
+	}
+
+	if err := getBankLines(&f); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.BankLines) != 2 || len(f.ChannelPolygons) != 1 || len(f.OverbankPolygons) != 2 {
+		t.Fatalf("got %d bank lines, %d channel and %d overbank polygons, want 2, 1 and 2", len(f.BankLines),
+			len(f.ChannelPolygons), len(f.OverbankPolygons))
+	}
+	left, err := pointsFromWKB(f.BankLines[0].Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][2]float64{{50, 100}, {50, 0}}; !reflect.DeepEqual(left, want) {
+		t.Errorf("got left bank line %v, want %v from upstream to downstream", left, want)
+	}
+}
@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ModelDiff lists the differences between a base model and a revised model
+type ModelDiff struct {
+	BaseFile    string `json:"Base File"`
+	RevisedFile string `json:"Revised File"`
+	Changes     []ModelChange
+}
+
+// ModelChange is a feature or value that was added, removed or changed in the revised model
+type ModelChange struct {
+	Category string
+	File     string
+	Feature  string
+	Field    string
+	Change   string
+	Base     interface{}
+	Revised  interface{}
+}
+
+// diffFields compares the exported fields of two structs of the same type, skipping the named fields
+func diffFields(category, file, feature string, base, revised interface{}, skip ...string) []ModelChange {
+	changes := []ModelChange{}
+	b, r := reflect.ValueOf(base), reflect.ValueOf(revised)
+	t := b.Type()
+fields:
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		for _, s := range skip {
+			if name == s {
+				continue fields
+			}
+		}
+		if t.Field(i).PkgPath != "" {
+			continue
+		}
+		bv, rv := b.Field(i).Interface(), r.Field(i).Interface()
+		if !reflect.DeepEqual(bv, rv) {
+			changes = append(changes, ModelChange{category, file, feature, name, "changed", bv, rv})
+		}
+	}
+	return changes
+}
+
+// diffKeys returns the keys of two maps that are only in the base, only in the revised and in both, sorted
+func diffKeys(base, revised map[string]interface{}) ([]string, []string, []string) {
+	removed, added, common := []string{}, []string{}, []string{}
+	for k := range base {
+		if _, ok := revised[k]; ok {
+			common = append(common, k)
+		} else {
+			removed = append(removed, k)
+		}
+	}
+	for k := range revised {
+		if _, ok := base[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+	sort.Strings(common)
+	return removed, added, common
+}
+
+// diffFiles matches two sets of files by extension and compares the contents of those in both
+func diffFiles(category string, base, revised map[string]interface{}, skip ...string) []ModelChange {
+	changes := []ModelChange{}
+	removed, added, common := diffKeys(base, revised)
+	for _, ext := range removed {
+		changes = append(changes, ModelChange{Category: category, File: ext, Change: "removed"})
+	}
+	for _, ext := range added {
+		changes = append(changes, ModelChange{Category: category, File: ext, Change: "added"})
+	}
+	for _, ext := range common {
+		changes = append(changes, diffFields(category, ext, "", base[ext], revised[ext], skip...)...)
+	}
+	return changes
+}
+
+// xsDiffValues returns the cross-section values compared between models
+func xsDiffValues(xs xsGeometry) map[string]interface{} {
+	mannings := [][2]float64{}
+	for _, m := range xs.Mannings {
+		mannings = append(mannings, [2]float64{m[0], m[1]})
+	}
+	return map[string]interface{}{
+		"Reach Lengths":     xs.Lengths,
+		"Station-Elevation": xs.Profile,
+		"Mannings N":        mannings,
+		"Bank Stations":     xs.BankStations,
+		"Ineffective Areas": xs.Ineffective,
+		"Levees":            xs.Levees,
+		"Cut Line":          xs.CutLine,
+	}
+}
+
+// diffCrossSections compares the cross-sections of two geometry files, matched by river, reach and river station
+func diffCrossSections(file string, base, revised []reachGeometry) []ModelChange {
+	changes := []ModelChange{}
+	index := func(reaches []reachGeometry) map[string]interface{} {
+		xss := map[string]interface{}{}
+		for _, reach := range reaches {
+			for _, xs := range reach.XS {
+				xss[xsFeatureName(reach, xs)] = xs
+			}
+		}
+		return xss
+	}
+	b, r := index(base), index(revised)
+
+	removed, added, common := diffKeys(b, r)
+	for _, name := range removed {
+		changes = append(changes, ModelChange{Category: "Cross-Section", File: file, Feature: name, Change: "removed"})
+	}
+	for _, name := range added {
+		changes = append(changes, ModelChange{Category: "Cross-Section", File: file, Feature: name, Change: "added"})
+	}
+	for _, name := range common {
+		bv, rv := xsDiffValues(b[name].(xsGeometry)), xsDiffValues(r[name].(xsGeometry))
+		fields := []string{}
+		for field := range bv {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			if !reflect.DeepEqual(bv[field], rv[field]) {
+				changes = append(changes, ModelChange{"Cross-Section", file, name, field, "changed", bv[field], rv[field]})
+			}
+		}
+	}
+	return changes
+}
+
+// structureIndex keys the culverts, bridges, inline weirs and lateral structures of a geometry file by type, reach and
+// river station, and its pump stations and rating curve outlets by type and name
+func structureIndex(structures []hydraulicStructures) map[string]interface{} {
+	index := map[string]interface{}{}
+	for _, hs := range structures {
+		reach := hs.River + ", " + hs.Reach
+		for _, c := range hs.CulvertData.Culverts {
+			index[fmt.Sprintf("Culvert|%s, %v", reach, c.Station)] = c
+		}
+		for _, b := range hs.BridgeData.Bridges {
+			index[fmt.Sprintf("Bridge|%s, %v", reach, b.Station)] = b
+		}
+		for _, w := range hs.WeirData.Weirs {
+			index[fmt.Sprintf("Inline Weir|%s, %v", reach, w.Station)] = w
+		}
+		for _, l := range hs.LateralData.LateralStructures {
+			index[fmt.Sprintf("Lateral Structure|%s, %v", reach, l.Station)] = l
+		}
+		for _, p := range hs.PumpData.PumpStations {
+			index["Pump Station|"+p.Name] = p
+		}
+		for _, o := range hs.OutletData.Outlets {
+			index["Rating Curve Outlet|"+o.Name] = o
+		}
+	}
+	return index
+}
+
+// diffStructures compares the hydraulic structures of two geometry files, matched by type, reach and river station
+func diffStructures(file string, base, revised []hydraulicStructures) []ModelChange {
+	changes := []ModelChange{}
+	b, r := structureIndex(base), structureIndex(revised)
+
+	split := func(key string) (string, string) {
+		parts := strings.SplitN(key, "|", 2)
+		return parts[0], parts[1]
+	}
+
+	removed, added, common := diffKeys(b, r)
+	for _, key := range removed {
+		category, name := split(key)
+		changes = append(changes, ModelChange{Category: category, File: file, Feature: name, Change: "removed"})
+	}
+	for _, key := range added {
+		category, name := split(key)
+		changes = append(changes, ModelChange{Category: category, File: file, Feature: name, Change: "added"})
+	}
+	for _, key := range common {
+		category, name := split(key)
+		changes = append(changes, diffFields(category, file, name, b[key], r[key], "Station")...)
+	}
+	return changes
+}
+
+// DiffModels compares the project metadata, plans, flows, cross-sections and hydraulic structures of two models.
+// Plan, flow and geometry files are matched by file extension.
+func DiffModels(base, revised *RasModel) (ModelDiff, error) {
+	diff := ModelDiff{
+		BaseFile:    base.Metadata.ProjFilePath,
+		RevisedFile: revised.Metadata.ProjFilePath,
+		Changes:     []ModelChange{},
+	}
+
+	diff.Changes = append(diff.Changes, diffFields("Project", filepath.Ext(base.Metadata.ProjFilePath), "", base.Metadata.ProjFileContents, revised.Metadata.ProjFileContents)...)
+	for _, v := range []struct {
+		field         string
+		base, revised string
+	}{
+		{"Version", base.Version, revised.Version},
+		{"Projection", base.Metadata.Projection, revised.Metadata.Projection},
+	} {
+		if v.base != v.revised {
+			diff.Changes = append(diff.Changes, ModelChange{"Project", filepath.Ext(base.Metadata.ProjFilePath), "", v.field, "changed", v.base, v.revised})
+		}
+	}
+
+	basePlans, revisedPlans := map[string]interface{}{}, map[string]interface{}{}
+	for _, p := range base.Metadata.PlanFiles {
+		basePlans[p.FileExt] = p
+	}
+	for _, p := range revised.Metadata.PlanFiles {
+		revisedPlans[p.FileExt] = p
+	}
+	diff.Changes = append(diff.Changes, diffFiles("Plan", basePlans, revisedPlans, "Path", "Notes")...)
+
+	baseFlows, revisedFlows := map[string]interface{}{}, map[string]interface{}{}
+	for _, f := range base.Metadata.FlowFiles {
+		baseFlows[f.FileExt] = f
+	}
+	for _, f := range revised.Metadata.FlowFiles {
+		revisedFlows[f.FileExt] = f
+	}
+	diff.Changes = append(diff.Changes, diffFiles("Flow", baseFlows, revisedFlows, "Path", "Notes")...)
+
+	baseGeoms, revisedGeoms := map[string]interface{}{}, map[string]interface{}{}
+	for _, g := range base.Metadata.GeomFiles {
+		baseGeoms[g.FileExt] = g
+	}
+	for _, g := range revised.Metadata.GeomFiles {
+		revisedGeoms[g.FileExt] = g
+	}
+	diff.Changes = append(diff.Changes, diffFiles("Geometry", baseGeoms, revisedGeoms, "Path", "Notes", "Structures", "Georeference")...)
+
+	_, _, common := diffKeys(baseGeoms, revisedGeoms)
+	for _, ext := range common {
+		bg, rg := baseGeoms[ext].(GeomFileContents), revisedGeoms[ext].(GeomFileContents)
+
+		baseXS, err := readXSGeometry(base.FileStore, bg.Path)
+		if err != nil {
+			return diff, err
+		}
+		revisedXS, err := readXSGeometry(revised.FileStore, rg.Path)
+		if err != nil {
+			return diff, err
+		}
+		diff.Changes = append(diff.Changes, diffCrossSections(ext, baseXS, revisedXS)...)
+		diff.Changes = append(diff.Changes, diffStructures(ext, bg.Structures, rg.Structures)...)
+	}
+	return diff, nil
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffModels(t *testing.T) {
+	xs := func(station string, mannings string, banks string) string {
+		return "Type RM Length L Ch R = 1 ," + station + "     ,100,100,100\n" +
+			"#Sta/Elev= 2\n       0     110     200     110\n" +
+			"#Mann= 3 , 0 , 0\n" + mannings + "\n" +
+			"Bank Sta=" + banks + "\n"
+	}
+	mannings := "       0     .06       0      50     .04       0     150     .06       0"
+	baseGeom := "River Reach=Creek           ,Main\n" +
+		xs("500", mannings, "50,150") + xs("400", mannings, "50,150") + xs("300", mannings, "50,150")
+	revisedGeom := "River Reach=Creek           ,Main\n" +
+		xs("500", "       0     .07       0      50     .04       0     150     .06       0", "50,150") +
+		xs("400", mannings, "60,150") + xs("350", mannings, "50,150")
+
+	structures := func(width float64) []hydraulicStructures {
+		return []hydraulicStructures{{River: "Creek", Reach: "Main", WeirData: weirData{NumWeirs: 1, Weirs: []weirs{
+			{Name: "Dam", Station: 450, NumGates: 1, Gates: []gates{{Name: "Gate #1", Type: "Sluice", Width: width, Height: 5}}},
+		}}}}
+	}
+
+	model := func(geom string, width float64) *RasModel {
+		rm, dir := testModel(t, map[string]string{"Test.g01": geom})
+		rm.Metadata.ProjFilePath = filepath.Join(dir, "Test.prj")
+		rm.Metadata.GeomFiles = []GeomFileContents{{Path: filepath.Join(dir, "Test.g01"), FileExt: ".g01", Structures: structures(width)}}
+		return rm
+	}
+
+	diff, err := DiffModels(model(baseGeom, 10), model(revisedGeom, 12))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ModelChange{
+		{Category: "Cross-Section", File: ".g01", Feature: "Creek, Main, 300", Change: "removed"},
+		{Category: "Cross-Section", File: ".g01", Feature: "Creek, Main, 350", Change: "added"},
+		{"Cross-Section", ".g01", "Creek, Main, 400", "Bank Stations", "changed", []float64{50, 150}, []float64{60, 150}},
+		{"Cross-Section", ".g01", "Creek, Main, 500", "Mannings N", "changed",
+			[][2]float64{{0, 0.06}, {50, 0.04}, {150, 0.06}}, [][2]float64{{0, 0.07}, {50, 0.04}, {150, 0.06}}},
+		{"Inline Weir", ".g01", "Creek, Main, 450", "Gates", "changed", structures(10)[0].WeirData.Weirs[0].Gates,
+			structures(12)[0].WeirData.Weirs[0].Gates},
+	}
+	if !reflect.DeepEqual(diff.Changes, want) {
+		t.Errorf("got changes\n%+v\nwant\n%+v", diff.Changes, want)
+	}
+}
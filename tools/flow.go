@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -79,3 +80,124 @@ func getFlowData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 	msg = ""
 	return
 }
+
+// gateSeries is a gate group's openings given at a boundary location of an unsteady flow file
+type gateSeries struct {
+	River    string
+	Reach    string
+	Station  float64
+	Gate     string
+	Openings gateOpenings
+}
+
+// getGateOpenings reads the "Gate Openings" time series of every gate group in an unsteady flow file
+func getGateOpenings(rm *RasModel, fn string) ([]gateSeries, error) {
+	series := []gateSeries{}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return series, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+
+	var river, reach string
+	var station float64
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "Boundary Location="):
+			data := strings.Split(rightofEquals(line), ",")
+			river, reach, station = "", "", 0
+			if len(data) > 2 {
+				river, reach = strings.TrimSpace(data[0]), strings.TrimSpace(data[1])
+				station, _ = stringtoFloat(data[2])
+			}
+
+		case strings.HasPrefix(line, "Gate Name="):
+			series = append(series, gateSeries{River: river, Reach: reach, Station: station, Gate: strings.TrimSpace(rightofEquals(line)),
+				Openings: gateOpenings{FlowFile: filepath.Base(fn), Openings: []float64{}}})
+
+		case len(series) == 0:
+			continue
+
+		case strings.HasPrefix(line, "Gate DSS Path="):
+			series[len(series)-1].Openings.DSSPath = strings.TrimSpace(rightofEquals(line))
+
+		case strings.HasPrefix(line, "Gate Use DSS="):
+			series[len(series)-1].Openings.UseDSS = strings.EqualFold(strings.TrimSpace(rightofEquals(line)), "True")
+
+		case strings.HasPrefix(line, "Gate Time Interval="):
+			series[len(series)-1].Openings.Interval = strings.TrimSpace(rightofEquals(line))
+
+		case strings.HasPrefix(line, "Gate Openings="):
+			n, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+			if err != nil {
+				return series, err
+			}
+			openings, _, err := fixedWidthValues(sc, 0, n, 80, 8)
+			if err != nil {
+				return series, err
+			}
+			series[len(series)-1].Openings.Openings = openings
+		}
+	}
+	return series, nil
+}
+
+// linkGateOpenings attaches the gate openings of the unsteady flow files planned with each geometry file to the
+// inline and lateral structure gates they control, matched by river, reach, river station and gate name
+func linkGateOpenings(rm *RasModel) {
+	for g := range rm.Metadata.GeomFiles {
+		geom := &rm.Metadata.GeomFiles[g]
+
+		flows := map[string]bool{}
+		for _, p := range rm.Metadata.PlanFiles {
+			if "."+strings.TrimSpace(p.GeomFile) == geom.FileExt {
+				flows["."+strings.TrimSpace(p.FlowFile)] = true
+			}
+		}
+
+		for _, flow := range rm.Metadata.FlowFiles {
+			if !flows[flow.FileExt] || !rasRE.Unsteady.MatchString(flow.FileExt) {
+				continue
+			}
+			series, err := getGateOpenings(rm, flow.Path)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+
+			for _, s := range series {
+				for h := range geom.Structures {
+					hs := &geom.Structures[h]
+					if hs.River != s.River || hs.Reach != s.Reach {
+						continue
+					}
+					for _, w := range hs.WeirData.Weirs {
+						if w.Station == s.Station {
+							attachGateOpenings(w.Gates, s)
+						}
+					}
+					for _, l := range hs.LateralData.LateralStructures {
+						if l.Station == s.Station {
+							attachGateOpenings(l.Gates, s)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// attachGateOpenings gives each gate its own copy of the openings so that converting units scales them once
+func attachGateOpenings(gs []gates, s gateSeries) {
+	for i := range gs {
+		if gs[i].Name == s.Gate {
+			openings := s.Openings
+			openings.Openings = append([]float64{}, s.Openings.Openings...)
+			gs[i].Openings = append(gs[i].Openings, openings)
+		}
+	}
+}
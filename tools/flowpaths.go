@@ -0,0 +1,239 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/USACE/filestore"
+	"github.com/USACE/mcat-ras/hdf"
+	"github.com/dewberry/gdal"
+)
+
+// riverFlowPaths is the group of the geometry HDF file holding the flow path lines drawn in RAS Mapper. Its attribute
+// table names the river, reach and type of each line, and each row of its polyline info gives the index of the first
+// point and the number of points of the line in the polyline points.
+const riverFlowPaths = "/Geometry/River Flow Paths/"
+
+// reachLengthTolerance is the fraction by which a measured reach length may differ from the reported length
+// before the cross-section is flagged
+const reachLengthTolerance float64 = 0.1
+
+// xsReachLengths compares the reach lengths reported on a cross-section with those measured to the next
+// cross-section downstream. A measured length of nil means it could not be determined.
+type xsReachLengths struct {
+	name     string
+	reported [3]float64
+	measured [3]interface{}
+	flag     bool
+}
+
+// overbankFlowPaths places a point in the middle of the left and right overbank of each cross-section
+func overbankFlowPaths(xss []xsGeometry) ([][2]float64, [][2]float64) {
+	left, right := [][2]float64{}, [][2]float64{}
+	for _, xs := range xss {
+		first, last := xs.Profile[0][0], xs.Profile[len(xs.Profile)-1][0]
+		left = append(left, xs.stationXY((first+xs.BankStations[0])/2))
+		right = append(right, xs.stationXY((xs.BankStations[1]+last)/2))
+	}
+	return left, right
+}
+
+// readStoredFlowPaths reads the left and right overbank flow paths of the geometry HDF file, keyed by reach name and
+// side. A geometry without flow paths returns an empty map.
+func readStoredFlowPaths(h hdfReader) (map[string]map[string][][2]float64, error) {
+	paths := map[string]map[string][][2]float64{}
+
+	rivers, err := h.ReadStrings(riverFlowPaths+"Attributes", "River Name")
+	if errors.Is(err, hdf.ErrNotFound) {
+		return paths, nil
+	}
+	if err != nil {
+		return paths, err
+	}
+	reaches, err := h.ReadStrings(riverFlowPaths+"Attributes", "Reach Name")
+	if err != nil {
+		return paths, err
+	}
+	types, err := h.ReadStrings(riverFlowPaths+"Attributes", "Type")
+	if err != nil {
+		return paths, err
+	}
+	info, infoShape, err := h.ReadFloats(riverFlowPaths + "Polyline Info")
+	if err != nil {
+		return paths, err
+	}
+	points, pointsShape, err := h.ReadFloats(riverFlowPaths + "Polyline Points")
+	if err != nil {
+		return paths, err
+	}
+	if len(infoShape) != 2 || infoShape[0] != len(rivers) || infoShape[1] < 2 || len(pointsShape) != 2 || pointsShape[1] != 2 {
+		return paths, fmt.Errorf("the flow path polylines do not match their %d attributes", len(rivers))
+	}
+
+	for i := range rivers {
+		var side string
+		switch t := strings.ToLower(types[i]); {
+		case strings.HasPrefix(t, "left"):
+			side = "Left"
+		case strings.HasPrefix(t, "right"):
+			side = "Right"
+		default:
+			continue
+		}
+
+		start, count := int(info[i*infoShape[1]]), int(info[i*infoShape[1]+1])
+		if start < 0 || count < 2 || start+count > pointsShape[0] {
+			return paths, fmt.Errorf("the %s flow path of %s, %s has invalid points", strings.ToLower(side), rivers[i], reaches[i])
+		}
+		line := make([][2]float64, count)
+		for j := range line {
+			line[j] = [2]float64{points[2*(start+j)], points[2*(start+j)+1]}
+		}
+
+		name := rivers[i] + ", " + reaches[i]
+		if paths[name] == nil {
+			paths[name] = map[string][][2]float64{}
+		}
+		paths[name][side] = line
+	}
+	return paths, nil
+}
+
+// lengthAlong returns the distance along a line between its crossings of two cut lines, or nil when either cut line
+// does not cross it
+func lengthAlong(line [][2]float64, us, ds [][2]float64) interface{} {
+	usCrossings := lineIntersections(us, line)
+	dsCrossings := lineIntersections(ds, line)
+	if len(usCrossings) == 0 || len(dsCrossings) == 0 {
+		return nil
+	}
+	return math.Abs(dsCrossings[0].measureB - usCrossings[0].measureB)
+}
+
+// compareReachLengths measures the overbank lengths between adjacent cross-sections along the stored flow paths of
+// the reach, keyed by side, or between the overbank midpoints when a side has none, and the channel length along the
+// river centerline, and flags those that differ from the reported lengths
+func compareReachLengths(reach reachGeometry, stored map[string][][2]float64) []xsReachLengths {
+	results := []xsReachLengths{}
+
+	for i := 0; i < len(reach.XS)-1; i++ {
+		us, ds := reach.XS[i], reach.XS[i+1]
+		result := xsReachLengths{name: us.Name, reported: us.Lengths}
+		if !us.hasGIS() || !ds.hasGIS() {
+			results = append(results, result)
+			continue
+		}
+
+		if len(us.BankStations) == 2 && len(ds.BankStations) == 2 {
+			left, right := overbankFlowPaths([]xsGeometry{us, ds})
+			result.measured[0] = distance(left[0], left[1])
+			result.measured[2] = distance(right[0], right[1])
+		}
+		if path, ok := stored["Left"]; ok {
+			result.measured[0] = lengthAlong(path, us.CutLine, ds.CutLine)
+		}
+		if path, ok := stored["Right"]; ok {
+			result.measured[2] = lengthAlong(path, us.CutLine, ds.CutLine)
+		}
+		result.measured[1] = lengthAlong(reach.Centerline, us.CutLine, ds.CutLine)
+
+		for j, measured := range result.measured {
+			m, ok := measured.(float64)
+			if !ok || result.reported[j] <= 0 {
+				continue
+			}
+			if math.Abs(m-result.reported[j]) > reachLengthTolerance*result.reported[j] {
+				result.flag = true
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// getFlowPaths extracts the left and right overbank flow paths of each reach, read from the geometry HDF file when it
+// stores them and otherwise built from the overbank midpoints of the cross-sections, and records on each cross-section
+// how its reported reach lengths compare with the distance to the next cross-section downstream. The lengths are
+// converted to the output units by lengthFactor.
+func getFlowPaths(f *Features, fs filestore.FileStore, geomFilePath string, transform gdal.CoordinateTransform, lengthFactor float64) error {
+	reaches, err := readXSGeometry(fs, geomFilePath)
+	if err != nil {
+		return err
+	}
+
+	stored := map[string]map[string][][2]float64{}
+	if geomHDF := geomFilePath + ".hdf"; fileExists(fs, geomHDF) {
+		h, err := openHDF(fs, geomHDF)
+		if err != nil {
+			return err
+		}
+		stored, err = readStoredFlowPaths(h)
+		h.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	xsLayers := map[string]VectorLayer{}
+	for _, xs := range f.XS {
+		xsLayers[fmt.Sprintf("%v|%s", xs.Fields["RiverReachName"], xs.FeatureName)] = xs
+	}
+
+	for _, reach := range reaches {
+		xss := []xsGeometry{}
+		for _, xs := range reach.XS {
+			if xs.hasGIS() && len(xs.BankStations) == 2 {
+				xss = append(xss, xs)
+			}
+		}
+
+		paths := map[string][][2]float64{}
+		if len(xss) >= 2 {
+			paths["Left"], paths["Right"] = overbankFlowPaths(xss)
+		}
+		for _, side := range []string{"Left", "Right"} {
+			points, source := stored[reach.name()][side], "Geometry"
+			if points == nil {
+				points, source = paths[side], "Overbank midpoints"
+			}
+			if points == nil {
+				continue
+			}
+			wkb, err := lineStringWKB(points, transform)
+			if err != nil {
+				return err
+			}
+			f.FlowPaths = append(f.FlowPaths, VectorLayer{
+				FeatureName: fmt.Sprintf("%s, %s Flow Path", reach.name(), side),
+				Fields:      map[string]interface{}{"RiverReachName": reach.name(), "Side": side, "Source": source},
+				Geometry:    wkb,
+			})
+		}
+
+		for _, result := range compareReachLengths(reach, stored[reach.name()]) {
+			xsName, err := toNumeric(result.name)
+			if err != nil {
+				return err
+			}
+			layer, ok := xsLayers[fmt.Sprintf("%s|%s", reach.name(), xsName)]
+			if !ok {
+				continue
+			}
+			for j, m := range result.measured {
+				if length, ok := m.(float64); ok {
+					result.measured[j] = length * lengthFactor
+				}
+			}
+			layer.Fields["LOBLength"] = result.reported[0] * lengthFactor
+			layer.Fields["ChannelLength"] = result.reported[1] * lengthFactor
+			layer.Fields["ROBLength"] = result.reported[2] * lengthFactor
+			layer.Fields["MeasuredLOBLength"] = result.measured[0]
+			layer.Fields["MeasuredChannelLength"] = result.measured[1]
+			layer.Fields["MeasuredROBLength"] = result.measured[2]
+			layer.Fields["ReachLengthFlag"] = result.flag
+		}
+	}
+	return nil
+}
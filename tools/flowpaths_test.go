@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/USACE/mcat-ras/hdf"
+)
+
+// memHDF is an in-memory HDF file. Numeric datasets are keyed by path and string datasets by path, or by path and
+// field joined with | for the fields of compound datasets.
+type memHDF struct {
+	floats map[string][]float64
+	shapes map[string][]int
+	texts  map[string][]string
+}
+
+func (m memHDF) ReadFloats(path string) ([]float64, []int, error) {
+	values, ok := m.floats[path]
+	if !ok {
+		return nil, nil, fmt.Errorf("%s: %w", path, hdf.ErrNotFound)
+	}
+	return values, m.shapes[path], nil
+}
+
+func (m memHDF) ReadStrings(path string, field string) ([]string, error) {
+	key := path
+	if field != "" {
+		key += "|" + field
+	}
+	values, ok := m.texts[key]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", key, hdf.ErrNotFound)
+	}
+	return values, nil
+}
+
+func TestReadStoredFlowPaths(t *testing.T) {
+	h := memHDF{
+		floats: map[string][]float64{
+			riverFlowPaths + "Polyline Info":   {0, 2, 0, 1, 2, 2, 0, 1, 4, 3, 0, 1},
+			riverFlowPaths + "Polyline Points": {0, 10, 0, 0, 5, 10, 5, 0, 10, 10, 11, 5, 10, 0},
+		},
+		shapes: map[string][]int{
+			riverFlowPaths + "Polyline Info":   {3, 4},
+			riverFlowPaths + "Polyline Points": {7, 2},
+		},
+		texts: map[string][]string{
+			riverFlowPaths + "Attributes|River Name": {"Creek", "Creek", "Creek"},
+			riverFlowPaths + "Attributes|Reach Name": {"Main", "Main", "Main"},
+			riverFlowPaths + "Attributes|Type":       {"Left Overbank", "Channel", "Right Overbank"},
+		},
+	}
+
+	got, err := readStoredFlowPaths(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string][][2]float64{"Creek, Main": {
+		"Left":  {{0, 10}, {0, 0}},
+		"Right": {{10, 10}, {11, 5}, {10, 0}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	h.shapes[riverFlowPaths+"Polyline Info"] = []int{2, 6}
+	if _, err := readStoredFlowPaths(h); err == nil {
+		t.Error("expected an error when the polylines do not match the attributes")
+	}
+
+	got, err = readStoredFlowPaths(memHDF{})
+	if err != nil || len(got) != 0 {
+		t.Errorf("got (%v, %v) for a geometry without flow paths, want no flow paths", got, err)
+	}
+}
+
+func TestCompareReachLengths(t *testing.T) {
+	reach := straightReach([]float64{0, 1000, 2000, 3000}, []float64{3000, 2000, 1000, 0})
+	lengths := [][3]float64{{1000, 1000, 1000}, {1090, 1000, 910}, {1000, 1200, 1000}, {0, 0, 0}}
+	for i := range reach.XS {
+		reach.XS[i].Profile = [][2]float64{{0, 10}, {200, 10}}
+		reach.XS[i].BankStations = []float64{50, 150}
+		reach.XS[i].Lengths = lengths[i]
+	}
+
+	testCases := []struct {
+		name   string
+		stored map[string][][2]float64
+		want   [][3]interface{}
+		flags  []bool
+	}{
+		{"overbank midpoints", nil,
+			[][3]interface{}{{1000.0, 1000.0, 1000.0}, {1000.0, 1000.0, 1000.0}, {1000.0, 1000.0, 1000.0}},
+			[]bool{false, false, true}},
+		{"stored left flow path", map[string][][2]float64{"Left": {{-75, 10100}, {-75, 9500}, {-15, 9500}, {-15, 9450}, {-75, 9450}, {-75, 6900}}},
+			[][3]interface{}{{1120.0, 1000.0, 1000.0}, {1000.0, 1000.0, 1000.0}, {1000.0, 1000.0, 1000.0}},
+			[]bool{true, false, true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			results := compareReachLengths(reach, tc.stored)
+			if len(results) != len(tc.want) {
+				t.Fatalf("got %d results, want %d", len(results), len(tc.want))
+			}
+			for i, result := range results {
+				match := result.name == reach.XS[i].Name && result.flag == tc.flags[i]
+				for j := range result.measured {
+					match = match && approxEqual(result.measured[j], tc.want[i][j])
+				}
+				if !match {
+					t.Errorf("cross-section %d: got %s %v flagged %v, want %s %v flagged %v", i, result.name, result.measured,
+						result.flag, reach.XS[i].Name, tc.want[i], tc.flags[i])
+				}
+			}
+		})
+	}
+}
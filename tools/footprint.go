@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dewberry/gdal"
+)
+
+// Footprint is the extent of a model's cross-sections, storage areas and 2D flow areas
+type Footprint struct {
+	Georeference int
+	Hull         string
+	BBox         [4]float64 `json:"bbox"`
+	Geometry     []uint8    `json:"geometry"`
+}
+
+func ringPolygon(ring [][2]float64) gdal.Geometry {
+	linearRing := gdal.Create(gdal.GT_LinearRing)
+	for _, p := range ring {
+		linearRing.AddPoint2D(p[0], p[1])
+	}
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		linearRing.AddPoint2D(ring[0][0], ring[0][1])
+	}
+	polygon := gdal.Create(gdal.GT_Polygon)
+	polygon.AddGeometryDirectly(linearRing)
+	return polygon
+}
+
+// reachCorridor connects the end points of a reach's cross-sections into a polygon
+func reachCorridor(reach reachGeometry) [][2]float64 {
+	var leftEdge, rightEdge [][2]float64
+	for _, xs := range reach.XS {
+		if len(xs.CutLine) < 2 {
+			continue
+		}
+		leftEdge = append(leftEdge, xs.CutLine[0])
+		rightEdge = append(rightEdge, xs.CutLine[len(xs.CutLine)-1])
+	}
+	if len(leftEdge) < 2 {
+		return nil
+	}
+	return append(leftEdge, reversed(rightEdge)...)
+}
+
+// ValidHull is true when hull is a footprint hull, convex or concave
+func ValidHull(hull string) bool {
+	return hull == "convex" || hull == "concave"
+}
+
+// Footprint returns the extent of the model in the destination coordinate reference system. A convex hull is
+// built from all cross-section, storage area and 2D flow area vertices. A concave footprint is not a concave hull of
+// those vertices but the union of each reach's corridor, the polygon joining the ends of its cross-section cut lines,
+// with the storage area and 2D flow area polygons, so features that do not touch remain separate polygons.
+func (rm *RasModel) Footprint(destinationCRS int, hull string) (Footprint, error) {
+	fp := Footprint{Georeference: destinationCRS, Hull: hull}
+
+	if rm.Metadata.Projection == "" {
+		return fp, errors.New("no valid coordinate reference system")
+	}
+	if !ValidHull(hull) {
+		return fp, fmt.Errorf("%s is not a valid hull, use convex or concave", hull)
+	}
+
+	points := gdal.Create(gdal.GT_MultiPoint)
+	defer points.Destroy()
+	polygons := []gdal.Geometry{}
+
+	addPoints := func(xyPairs [][2]float64) {
+		for _, p := range xyPairs {
+			point := gdal.Create(gdal.GT_Point)
+			point.AddPoint2D(p[0], p[1])
+			points.AddGeometryDirectly(point)
+		}
+	}
+
+	for _, g := range rm.Metadata.GeomFiles {
+		reaches, err := readXSGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return fp, err
+		}
+		for _, reach := range reaches {
+			for _, xs := range reach.XS {
+				addPoints(xs.CutLine)
+			}
+			if hull == "concave" {
+				corridor := reachCorridor(reach)
+				if corridor == nil {
+					continue
+				}
+				polygons = append(polygons, ringPolygon(corridor))
+			}
+		}
+
+		storageAreas, err := readStorageAreaGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return fp, err
+		}
+		for _, sa := range storageAreas {
+			if len(sa.Perimeter) < 3 {
+				continue
+			}
+			addPoints(sa.Perimeter)
+			if hull == "concave" {
+				polygons = append(polygons, ringPolygon(sa.Perimeter))
+			}
+		}
+	}
+
+	if points.GeometryCount() < 3 {
+		return fp, errors.New("the model does not have enough georeferenced features to build a footprint")
+	}
+
+	var footprint gdal.Geometry
+	switch hull {
+	case "convex":
+		footprint = points.ConvexHull()
+
+	case "concave":
+		footprint = gdal.Create(gdal.GT_MultiPolygon)
+		for _, polygon := range polygons {
+			// buffer by zero to repair corridors whose cross-sections cross
+			repaired := polygon.Buffer(0, 8)
+			union := footprint.Union(repaired)
+			footprint.Destroy()
+			repaired.Destroy()
+			polygon.Destroy()
+			footprint = union
+		}
+	}
+
+	transform, err := getTransform(rm.Metadata.Projection, destinationCRS)
+	if err != nil {
+		return fp, err
+	}
+	defer transform.Destroy()
+	footprint.Transform(transform)
+	multiPolygon := footprint.ForceToMultiPolygon()
+	defer multiPolygon.Destroy()
+
+	env := multiPolygon.Envelope()
+	fp.BBox = [4]float64{env.MinX(), env.MinY(), env.MaxX(), env.MaxY()}
+
+	wkb, err := multiPolygon.ToWKB()
+	if err != nil {
+		return fp, err
+	}
+	fp.Geometry = wkb
+	return fp, nil
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dewberry/gdal"
+)
+
+func TestReachCorridor(t *testing.T) {
+	reach := reachGeometry{XS: []xsGeometry{
+		{CutLine: [][2]float64{{-100, 500}, {0, 510}, {100, 500}}},
+		{CutLine: nil},
+		{CutLine: [][2]float64{{-100, 400}, {100, 400}}},
+	}}
+	want := [][2]float64{{-100, 500}, {-100, 400}, {100, 400}, {100, 500}}
+	if got := reachCorridor(reach); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	reach.XS = reach.XS[:2]
+	if got := reachCorridor(reach); got != nil {
+		t.Errorf("got %v for a reach with one cut line, want no corridor", got)
+	}
+}
+
+func TestFootprint(t *testing.T) {
+	geom := `River Reach=Creek           ,Main
+Type RM Length L Ch R = 1 ,500     ,100,100,100
+XS GIS Cut Line=2
+            -100             500             100             500
+Type RM Length L Ch R = 1 ,400     ,100,100,100
+XS GIS Cut Line=2
+            -100             400             100             400
+Storage Area=Pond            ,,
+Storage Area Surface Line= 4
+             200             400
+             300             400
+             300             500
+             200             500
+`
+	rm, dir := testModel(t, map[string]string{"Test.g01": geom})
+	rm.Metadata.Projection = epsgWKT(t, 26915)
+	rm.Metadata.GeomFiles = []GeomFileContents{{Path: filepath.Join(dir, "Test.g01"), FileExt: ".g01"}}
+
+	// the corridor and the storage area are 100 apart, bridged by the convex hull only
+	testCases := []struct {
+		hull     string
+		polygons int
+		area     float64
+	}{
+		{"convex", 1, 40000},
+		{"concave", 2, 30000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hull, func(t *testing.T) {
+			fp, err := rm.Footprint(26915, tc.hull)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fp.BBox != [4]float64{-100, 400, 300, 500} {
+				t.Errorf("got bbox %v, want [-100 400 300 500]", fp.BBox)
+			}
+			geometry, err := gdal.CreateFromWKB(fp.Geometry, gdal.SpatialReference{}, len(fp.Geometry))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer geometry.Destroy()
+			if n := geometry.GeometryCount(); n != tc.polygons {
+				t.Errorf("got %d polygons, want %d", n, tc.polygons)
+			}
+			if area := geometry.Area(); math.Abs(area-tc.area) > 1e-6 {
+				t.Errorf("got an area of %v, want %v", area, tc.area)
+			}
+		})
+	}
+
+	if _, err := rm.Footprint(26915, "alpha"); err == nil {
+		t.Error("expected an error for an invalid hull")
+	}
+}
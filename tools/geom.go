@@ -16,6 +16,7 @@ type GeomFileContents struct {
 	ProgramVersion string                `json:"Program Version"`
 	Description    string                `json:"Description"`
 	Structures     []hydraulicStructures `json:"Hydraulic Structures"`
+	Georeference   GeoreferenceReport    `json:"Georeference"`
 	Notes          string
 }
 
@@ -45,6 +46,7 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 	sc := bufio.NewScanner(f)
 
 	var description string
+	stations, ratingOutlets := []pumpStations{}, []outlets{}
 
 	header := true
 	idx := 0
@@ -78,8 +80,34 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 
 		case strings.HasPrefix(line, "Storage Area="):
 			header = false
+
+		case strings.HasPrefix(line, "Pump Station="):
+			pump, err := getPumpStationData(rm, fn, idx)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			stations = append(stations, pump)
+			header = false
+
+		case strings.HasPrefix(line, "Connection="):
+			outlet, ok, err := getOutletData(rm, fn, idx)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			if ok {
+				ratingOutlets = append(ratingOutlets, outlet)
+			}
+			header = false
 		}
 	}
+	meta.Structures = addStorageAreaStructures(meta.Structures, stations, ratingOutlets)
+
+	meta.Georeference, err = getGeoreferenceReport(rm.FileStore, fn)
+	if err != nil {
+		return
+	}
 	msg = ""
 	return
 }
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"bufio"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/USACE/filestore"
+)
+
+// GeoreferenceReport describes how much of a geometry file's content is georeferenced.
+// Percentages are 0 when the file has no features of that type. A file is geospatial ready when it has
+// reaches with coordinates and cross-sections with cut lines, or storage areas with surface lines. Cross-sections
+// without cut lines are left out of the geospatial data of a ready file.
+type GeoreferenceReport struct {
+	Reaches                      int     `json:"Reaches"`
+	ReachesWithXY                int     `json:"Reaches With XY"`
+	CrossSections                int     `json:"Cross Sections"`
+	CrossSectionsWithCutLines    int     `json:"Cross Sections With Cut Lines"`
+	CutLineProfileMatches        int     `json:"Cut Line Profile Matches"`
+	StorageAreas                 int     `json:"Storage Areas"`
+	StorageAreasWithSurfaceLines int     `json:"Storage Areas With Surface Lines"`
+	PctReachesWithXY             float64 `json:"Percent Reaches With XY"`
+	PctCrossSectionsWithCutLines float64 `json:"Percent Cross Sections With Cut Lines"`
+	PctCutLineProfileMatches     float64 `json:"Percent Cut Line Profile Matches"`
+	PctStorageAreasWithSurface   float64 `json:"Percent Storage Areas With Surface Lines"`
+	Ready                        bool    `json:"Geospatial Ready"`
+}
+
+func percent(n int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(10000*float64(n)/float64(total)) / 100
+}
+
+func nDataPairs(line string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+}
+
+// getGeoreferenceReport scans a geometry file for river centerline coordinates, cross-section cut lines and
+// storage area surface lines, and checks whether each cut line is as long as its station-elevation profile
+func getGeoreferenceReport(fs filestore.FileStore, fn string) (GeoreferenceReport, error) {
+	report := GeoreferenceReport{}
+
+	f, err := fs.GetObject(fn)
+	if err != nil {
+		return report, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+
+	inXS := false
+	var cutLine, profile [][2]float64
+	endXS := func() {
+		if !inXS {
+			return
+		}
+		if len(cutLine) >= 2 {
+			report.CrossSectionsWithCutLines++
+			if len(profile) >= 2 {
+				lenCutLine := 0.0
+				for i := 1; i < len(cutLine); i++ {
+					lenCutLine += distance(cutLine[i-1], cutLine[i])
+				}
+				lenProfile := profile[len(profile)-1][0] - profile[0][0]
+				if math.Abs(lenProfile-lenCutLine) <= 0.1 {
+					report.CutLineProfileMatches++
+				}
+			}
+		}
+		inXS = false
+		cutLine, profile = nil, nil
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "River Reach="):
+			endXS()
+			report.Reaches++
+
+		case strings.HasPrefix(line, "Reach XY="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return report, err
+			}
+			if n > 0 {
+				report.ReachesWithXY++
+			}
+
+		case strings.HasPrefix(line, "Storage Area="):
+			endXS()
+			report.StorageAreas++
+
+		case strings.HasPrefix(line, "Storage Area Surface Line="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return report, err
+			}
+			if n > 0 {
+				report.StorageAreasWithSurfaceLines++
+			}
+
+		case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			endXS()
+			data := strings.Split(rightofEquals(line), ",")
+			if strings.TrimSpace(data[0]) == "1" {
+				report.CrossSections++
+				inXS = true
+			}
+
+		case inXS && strings.HasPrefix(line, "XS GIS Cut Line="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return report, err
+			}
+			if n > 0 {
+				cutLine, err = dataPairsfromTextBlock(sc, n, 64, 16)
+				if err != nil {
+					return report, err
+				}
+			}
+
+		case inXS && strings.HasPrefix(line, "#Sta/Elev="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return report, err
+			}
+			if n > 0 {
+				profile, err = dataPairsfromTextBlock(sc, n, 80, 8)
+				if err != nil {
+					return report, err
+				}
+			}
+		}
+	}
+	endXS()
+
+	report.PctReachesWithXY = percent(report.ReachesWithXY, report.Reaches)
+	report.PctCrossSectionsWithCutLines = percent(report.CrossSectionsWithCutLines, report.CrossSections)
+	report.PctCutLineProfileMatches = percent(report.CutLineProfileMatches, report.CrossSectionsWithCutLines)
+	report.PctStorageAreasWithSurface = percent(report.StorageAreasWithSurfaceLines, report.StorageAreas)
+
+	// features such as interpolated cross-sections often have no GIS data, so a file is ready when its reaches and
+	// storage areas have georeferenced features, and the percentages report how complete they are
+	reachesReady := report.Reaches == 0 ||
+		(report.ReachesWithXY > 0 && (report.CrossSections == 0 || report.CrossSectionsWithCutLines > 0))
+	storageAreasReady := report.StorageAreas == 0 || report.StorageAreasWithSurfaceLines > 0
+	report.Ready = report.Reaches+report.StorageAreas > 0 && reachesReady && storageAreasReady
+
+	return report, nil
+}
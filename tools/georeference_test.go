@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGetGeoreferenceReport(t *testing.T) {
+	reach := `River Reach=Creek           ,Main
+Reach XY= 2
+               0            1000               0               0
+`
+	xs := `Type RM Length L Ch R = 1 ,500     ,100,100,100
+XS GIS Cut Line=2
+            -100             500             100             500
+#Sta/Elev= 2
+       0     110     200     110
+`
+	interpolated := `Type RM Length L Ch R = 1 ,450*    ,100,100,100
+#Sta/Elev= 2
+       0     110     200     110
+`
+	storageArea := `Storage Area=Pond,100,200
+Storage Area Surface Line= 3
+               0               0              10               0              10              10
+`
+	tests := []struct {
+		name       string
+		geom       string
+		ready      bool
+		pctCutLine float64
+	}{
+		{"every cross-section has a cut line", reach + xs, true, 100},
+		{"an interpolated cross-section without a cut line", reach + xs + interpolated, true, 50},
+		{"no cut lines", reach + interpolated, false, 0},
+		{"a reach without coordinates", "River Reach=Creek,Main\n" + xs, false, 100},
+		{"storage areas only", storageArea, true, 0},
+		{"empty geometry file", "Geom Title=Empty\n", false, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rm, dir := testModel(t, map[string]string{"Test.g01": tc.geom})
+			report, err := getGeoreferenceReport(rm.FileStore, filepath.Join(dir, "Test.g01"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Ready != tc.ready || report.PctCrossSectionsWithCutLines != tc.pctCutLine {
+				t.Errorf("got ready %v with %v%% cut lines, want %v with %v%%", report.Ready,
+					report.PctCrossSectionsWithCutLines, tc.ready, tc.pctCutLine)
+			}
+		})
+	}
+}
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/USACE/filestore"
 	"github.com/dewberry/gdal"
@@ -19,6 +22,9 @@ import (
 type GeoData struct {
 	Features     map[string]Features
 	Georeference int
+	Units        string
+	Warnings     []string
+	Inundation   map[string][]VectorLayer `json:",omitempty"`
 }
 
 // Features ...
@@ -26,9 +32,14 @@ type Features struct {
 	Rivers              []VectorLayer
 	XS                  []VectorLayer
 	Banks               []VectorLayer
+	BankLines           []VectorLayer
+	ChannelPolygons     []VectorLayer
+	OverbankPolygons    []VectorLayer
+	FlowPaths           []VectorLayer
 	StorageAreas        []VectorLayer
 	TwoDAreas           []VectorLayer
 	HydraulicStructures []VectorLayer
+	PumpStations        []VectorLayer
 }
 
 // VectorLayer ...
@@ -44,23 +55,6 @@ type xyzPoint struct {
 	z float64
 }
 
-var unitConsistencyMap map[string]string = map[string]string{
-	"English Units": "US survey foot",
-	"SI Units":      "metre"}
-
-// checkUnitConsistency checks that the unit system used by the model and its coordinate reference system are the same
-func checkUnitConsistency(modelUnits string, sourceCRS string) error {
-	sourceSpRef := gdal.CreateSpatialReference(sourceCRS)
-
-	if crsUnits, ok := sourceSpRef.AttrValue("UNIT", 0); ok {
-		if unitConsistencyMap[modelUnits] == crsUnits {
-			return nil
-		}
-		return errors.New("The unit system of the model and coordinate reference system are inconsistent")
-	}
-	return errors.New("Unable to check unit consistency, could not identify the coordinate reference system's units")
-}
-
 func dataPairsfromTextBlock(sc *bufio.Scanner, nPairs int, colWidth int, valueWidth int) ([][2]float64, error) {
 	var stride int = valueWidth * 2
 	pairs := [][2]float64{}
@@ -156,6 +150,76 @@ findLineSegment:
 	return newPoint
 }
 
+// polylineLength returns the length of a line composed of many segments
+func polylineLength(xyPairs [][2]float64) float64 {
+	length := 0.0
+	for i := 1; i < len(xyPairs); i++ {
+		length += distance(xyPairs[i-1], xyPairs[i])
+	}
+	return length
+}
+
+// segmentIntersection returns the point where segments p0-p1 and q0-q1 cross and how far along each segment
+// it lies as a fraction of the segment's length
+func segmentIntersection(p0, p1, q0, q1 [2]float64) ([2]float64, float64, float64, bool) {
+	r := [2]float64{p1[0] - p0[0], p1[1] - p0[1]}
+	s := [2]float64{q1[0] - q0[0], q1[1] - q0[1]}
+	denom := r[0]*s[1] - r[1]*s[0]
+	if denom == 0 {
+		return [2]float64{}, 0, 0, false
+	}
+	qp := [2]float64{q0[0] - p0[0], q0[1] - p0[1]}
+	t := (qp[0]*s[1] - qp[1]*s[0]) / denom
+	u := (qp[0]*r[1] - qp[1]*r[0]) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return [2]float64{}, t, u, false
+	}
+	return [2]float64{p0[0] + t*r[0], p0[1] + t*r[1]}, t, u, true
+}
+
+// lineIntersection is a point where two lines cross and its distance along each line
+type lineIntersection struct {
+	point    [2]float64
+	measureA float64
+	measureB float64
+}
+
+// intersectionTolerance is the distance within which crossings found on adjacent segments are the same point
+const intersectionTolerance float64 = 1e-6
+
+// lineIntersections returns the points where line a crosses line b, ordered along line a. A crossing at a vertex
+// shared by two segments is returned once.
+func lineIntersections(a, b [][2]float64) []lineIntersection {
+	intersections := []lineIntersection{}
+	found := func(point [2]float64) bool {
+		for _, x := range intersections {
+			if distance(x.point, point) <= intersectionTolerance {
+				return true
+			}
+		}
+		return false
+	}
+
+	measureA := 0.0
+	for i := 1; i < len(a); i++ {
+		measureB := 0.0
+		for j := 1; j < len(b); j++ {
+			point, t, u, ok := segmentIntersection(a[i-1], a[i], b[j-1], b[j])
+			if ok && !found(point) {
+				intersections = append(intersections, lineIntersection{
+					point:    point,
+					measureA: measureA + t*distance(a[i-1], a[i]),
+					measureB: measureB + u*distance(b[j-1], b[j]),
+				})
+			}
+			measureB += distance(b[j-1], b[j])
+		}
+		measureA += distance(a[i-1], a[i])
+	}
+	sort.SliceStable(intersections, func(i, j int) bool { return intersections[i].measureA < intersections[j].measureA })
+	return intersections
+}
+
 // attributeZ using station from cross-section line and gis coordinates
 func attributeZ(xyPairs [][2]float64, mzPairs [][2]float64) []xyzPoint {
 	points := []xyzPoint{}
@@ -172,11 +236,70 @@ func attributeZ(xyPairs [][2]float64, mzPairs [][2]float64) []xyzPoint {
 	return points
 }
 
+// axisMappingStrategy is the GDAL configuration option that sets the axis order of new spatial references.
+// Traditional GIS order keeps coordinates as x/y (longitude/latitude, easting/northing) for every coordinate
+// reference system instead of the order defined by its authority, such as latitude/longitude for EPSG:4326.
+const axisMappingStrategy string = "OSR_DEFAULT_AXIS_MAPPING_STRATEGY"
+
+var axisOrder struct {
+	once sync.Once
+	err  error
+}
+
+func init() {
+	if os.Getenv(axisMappingStrategy) == "" {
+		os.Setenv(axisMappingStrategy, "TRADITIONAL_GIS_ORDER")
+	}
+}
+
+// checkAxisOrder rejects GDAL versions older than 3.5, which ignore the axis mapping option, and transforms a point
+// 10 degrees north of the origin from EPSG:3857 to EPSG:4326 to confirm that GDAL returns longitude before latitude
+func checkAxisOrder() error {
+	axisOrder.once.Do(func() {
+		if gdal.VERSION_MAJOR < 3 || (gdal.VERSION_MAJOR == 3 && gdal.VERSION_MINOR < 5) {
+			axisOrder.err = fmt.Errorf("GDAL %d.%d does not support %s, GDAL 3.5 or later is required to return coordinates in x/y order",
+				gdal.VERSION_MAJOR, gdal.VERSION_MINOR, axisMappingStrategy)
+			return
+		}
+		sourceSpRef := gdal.CreateSpatialReference("")
+		defer sourceSpRef.Destroy()
+		destinationSpRef := gdal.CreateSpatialReference("")
+		defer destinationSpRef.Destroy()
+		if err := sourceSpRef.FromEPSG(3857); err != nil {
+			axisOrder.err = err
+			return
+		}
+		if err := destinationSpRef.FromEPSG(4326); err != nil {
+			axisOrder.err = err
+			return
+		}
+
+		transform := gdal.CreateCoordinateTransform(sourceSpRef, destinationSpRef)
+		defer transform.Destroy()
+		x, y, z := []float64{0}, []float64{1118889.97}, []float64{0}
+		if !transform.Transform(1, x, y, z) {
+			axisOrder.err = errors.New("unable to check the coordinate axis order")
+			return
+		}
+		if math.Abs(x[0]) > 1e-6 || math.Abs(y[0]-10) > 1e-3 {
+			axisOrder.err = fmt.Errorf("coordinates are not returned in x/y order, set %s=TRADITIONAL_GIS_ORDER with GDAL 3.5 or later", axisMappingStrategy)
+		}
+	})
+	return axisOrder.err
+}
+
+// getTransform returns a transformation from the model's coordinate reference system to the destination EPSG code
+// that takes and returns coordinates in x/y order
 func getTransform(sourceCRS string, destinationCRS int) (gdal.CoordinateTransform, error) {
 	transform := gdal.CoordinateTransform{}
+	if err := checkAxisOrder(); err != nil {
+		return transform, err
+	}
 	sourceSpRef := gdal.CreateSpatialReference(sourceCRS)
+	defer sourceSpRef.Destroy()
 
 	destinationSpRef := gdal.CreateSpatialReference("")
+	defer destinationSpRef.Destroy()
 	if err := destinationSpRef.FromEPSG(destinationCRS); err != nil {
 		return transform, err
 	}
@@ -184,48 +307,17 @@ func getTransform(sourceCRS string, destinationCRS int) (gdal.CoordinateTransfor
 	return transform, nil
 }
 
-func flipXYLineString(xyLineString gdal.Geometry) gdal.Geometry {
-	yxLineString := gdal.Create(gdal.GT_LineString)
-	nPoints := xyLineString.PointCount()
-	for i := 0; i < nPoints; i++ {
-		x, y, _ := xyLineString.Point(i)
-		yxLineString.AddPoint2D(y, x)
+// lineStringWKB transforms a line given in model coordinates and returns it as a multi line string
+func lineStringWKB(xyPairs [][2]float64, transform gdal.CoordinateTransform) ([]uint8, error) {
+	xyLineString := gdal.Create(gdal.GT_LineString)
+	for _, pair := range xyPairs {
+		xyLineString.AddPoint2D(pair[0], pair[1])
 	}
-	xyLineString.Destroy()
-	return yxLineString
-}
 
-func flipXYLineString25D(xyzLineString gdal.Geometry) gdal.Geometry {
-	yxzLineString := gdal.Create(gdal.GT_LineString25D)
-	nPoints := xyzLineString.PointCount()
-	for i := 0; i < nPoints; i++ {
-		x, y, z := xyzLineString.Point(i)
-		yxzLineString.AddPoint(y, x, z)
-	}
-	xyzLineString.Destroy()
-	return yxzLineString
-}
+	xyLineString.Transform(transform)
 
-func flipXYLinearRing(xyLinearRing gdal.Geometry) gdal.Geometry {
-	yxLinearRing := gdal.Create(gdal.GT_LinearRing)
-	nPoints := xyLinearRing.PointCount()
-	for i := 0; i < nPoints; i++ {
-		x, y, _ := xyLinearRing.Point(i)
-		yxLinearRing.AddPoint2D(y, x)
-	}
-	xyLinearRing.Destroy()
-	return yxLinearRing
-}
-
-func flipXYPoint(xyPoint gdal.Geometry) gdal.Geometry {
-	yxPoint := gdal.Create(gdal.GT_Point)
-	nPoints := xyPoint.PointCount()
-	for i := 0; i < nPoints; i++ {
-		x, y, _ := xyPoint.Point(i)
-		yxPoint.AddPoint2D(y, x)
-	}
-	xyPoint.Destroy()
-	return yxPoint
+	multiLineString := xyLineString.ForceToMultiLineString()
+	return multiLineString.ToWKB()
 }
 
 func toNumeric(s string) (string, error) {
@@ -252,10 +344,8 @@ func getRiverCenterline(sc *bufio.Scanner, transform gdal.CoordinateTransform) (
 	}
 
 	xyLineString.Transform(transform)
-	// This is a temporary fix since the x and y values need to be flipped:
-	yxLineString := flipXYLineString(xyLineString)
 
-	multiLineString := yxLineString.ForceToMultiLineString()
+	multiLineString := xyLineString.ForceToMultiLineString()
 
 	wkb, err := multiLineString.ToWKB()
 	if err != nil {
@@ -265,91 +355,150 @@ func getRiverCenterline(sc *bufio.Scanner, transform gdal.CoordinateTransform) (
 	return layer, err
 }
 
-func getXSBanks(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string) (VectorLayer, []VectorLayer, error) {
+// getXSBanks extracts a cross-section and its bank stations. Cross-sections without a cut line are not extracted.
+func getXSBanks(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string, scaleStations bool, lengthFactor float64) (VectorLayer, []VectorLayer, bool, error) {
 	bankLayers := []VectorLayer{}
 
-	xsLayer, xyPairs, startingStation, err := getXS(sc, transform, riverReachName)
-	if err != nil {
-		return xsLayer, bankLayers, err
+	xsLayer, xyPairs, startingStation, stationScale, ok, err := getXS(sc, transform, riverReachName, scaleStations, lengthFactor)
+	if err != nil || !ok {
+		return xsLayer, bankLayers, ok, err
 	}
 	log.Println("Extracted cross-section")
-	if xsLayer.Fields["CutLineProfileMatch"].(bool) {
+	if xsLayer.Fields["InterpolationMethod"] != "None" {
 		for sc.Scan() {
 			line := sc.Text()
 			if strings.HasPrefix(line, "Bank Sta=") {
-				bankLayers, err = getBanks(line, transform, xsLayer, xyPairs, startingStation)
+				bankLayers, err = getBanks(line, transform, xsLayer, xyPairs, startingStation, stationScale)
 				if err != nil {
-					return xsLayer, bankLayers, err
+					return xsLayer, bankLayers, ok, err
 				}
 				break
 			}
 		}
 	}
 	log.Println("Extracted banks")
-	return xsLayer, bankLayers, err
+	return xsLayer, bankLayers, ok, err
 }
 
-func getXS(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string) (VectorLayer, [][2]float64, float64, error) {
+// getXSCutLineProfile reads a cross-section's cut line and station-elevation profile. The cut line precedes the
+// profile in a cross-section block, so the search for it stops at the profile and a cross-section without a cut line
+// is not given the cut line of the next one.
+func getXSCutLineProfile(sc *bufio.Scanner) ([][2]float64, [][2]float64, error) {
 	xyPairs := [][2]float64{}
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "XS GIS Cut Line="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return xyPairs, nil, err
+			}
+			xyPairs, err = dataPairsfromTextBlock(sc, n, 64, 16)
+			if err != nil {
+				return xyPairs, nil, err
+			}
+
+		case strings.HasPrefix(line, "#Sta/Elev="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return xyPairs, nil, err
+			}
+			mzPairs, err := dataPairsfromTextBlock(sc, n, 80, 8)
+			return xyPairs, mzPairs, err
+
+		case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			return xyPairs, nil, errors.New("the cross-section block ended before its station-elevation profile")
+		}
+	}
+	return xyPairs, nil, errors.New("the geometry file ended before the cross-section's station-elevation profile")
+}
+
+// matchProfile compares the length of a cross-section's station-elevation profile with its cut line and sets the
+// layer's "CutLineProfileMatch", "InterpolationMethod" and "LengthMismatchRatio" fields. When the lengths differ and
+// scaleStations is set, stations are scaled proportionally from the first station. It returns the profile to drape
+// onto the cut line and the station scale.
+func matchProfile(layer *VectorLayer, mzPairs [][2]float64, lenCutLine float64, scaleStations bool) ([][2]float64, float64) {
+	if len(mzPairs) < 2 {
+		return mzPairs, 1
+	}
+	lenProfile := mzPairs[len(mzPairs)-1][0] - mzPairs[0][0]
+	if lenProfile > 0 {
+		layer.Fields["LengthMismatchRatio"] = lenCutLine / lenProfile
+	}
+
+	switch {
+	case math.Abs(lenProfile-lenCutLine) <= 0.1:
+		layer.Fields["CutLineProfileMatch"] = true
+		layer.Fields["InterpolationMethod"] = "Exact"
+
+	case scaleStations && lenProfile > 0:
+		stationScale := lenCutLine / lenProfile
+		scaledPairs := make([][2]float64, len(mzPairs))
+		for i, pair := range mzPairs {
+			scaledPairs[i] = [2]float64{mzPairs[0][0] + (pair[0]-mzPairs[0][0])*stationScale, pair[1]}
+		}
+		layer.Fields["InterpolationMethod"] = "Proportional"
+		return scaledPairs, stationScale
+	}
+	return mzPairs, 1
+}
+
+// getXS extracts a cross-section cut line and drapes the station-elevation profile onto it. When the cut line
+// and profile lengths differ and scaleStations is set, stations are scaled proportionally along the cut line.
+// Cross-sections without a cut line are reported as not extracted.
+func getXS(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string, scaleStations bool, lengthFactor float64) (VectorLayer, [][2]float64, float64, float64, bool, error) {
+	stationScale := 1.0
 	layer := VectorLayer{Fields: map[string]interface{}{}}
 	layer.Fields["RiverReachName"] = riverReachName
 	layer.Fields["CutLineProfileMatch"] = false
+	layer.Fields["InterpolationMethod"] = "None"
+	layer.Fields["LengthMismatchRatio"] = nil
 
 	compData := strings.Split(rightofEquals(sc.Text()), ",")
 
 	xsName, err := toNumeric(compData[1])
 	if err != nil {
-		return layer, xyPairs, 0.0, err
+		return layer, nil, 0.0, stationScale, false, err
 	}
 	layer.FeatureName = xsName
 
-	xyPairs, err = getDataPairsfromTextBlock("XS GIS Cut Line", sc, 64, 16)
+	xyPairs, mzPairs, err := getXSCutLineProfile(sc)
 	if err != nil {
-		return layer, xyPairs, 0.0, err
+		return layer, xyPairs, 0.0, stationScale, false, fmt.Errorf("cross-section %s, %s: %s", riverReachName, xsName, err)
 	}
-
 	if len(xyPairs) < 2 {
-		err = errors.New("the cross-section cutline could not be extracted, check that the geometry file contains cutlines")
-		return layer, xyPairs, 0.0, err
+		return layer, xyPairs, 0.0, stationScale, false, nil
 	}
-
-	xyzLineString := gdal.Create(gdal.GT_LineString25D)
-	for _, pair := range xyPairs {
-		xyzLineString.AddPoint(pair[0], pair[1], 0.0)
+	if len(mzPairs) == 0 {
+		return layer, xyPairs, 0.0, stationScale, false, fmt.Errorf("cross-section %s, %s has an empty station-elevation profile", riverReachName, xsName)
 	}
-	lenCutLine := xyzLineString.Length()
 
-	mzPairs, err := getDataPairsfromTextBlock("#Sta/Elev", sc, 80, 8)
-	if err != nil {
-		return layer, xyPairs, mzPairs[0][0], err
-	}
+	mzPairs, stationScale = matchProfile(&layer, mzPairs, polylineLength(xyPairs), scaleStations)
 
-	if len(mzPairs) >= 2 {
-		lenProfile := mzPairs[len(mzPairs)-1][0] - mzPairs[0][0]
-		if math.Abs(lenProfile-lenCutLine) <= 0.1 {
-			xyzPoints := attributeZ(xyPairs, mzPairs)
-			xyzLineString = gdal.Create(gdal.GT_LineString25D)
-			for _, point := range xyzPoints {
-				xyzLineString.AddPoint(point.x, point.y, point.z)
-			}
-			layer.Fields["CutLineProfileMatch"] = true
+	xyzLineString := gdal.Create(gdal.GT_LineString25D)
+	if layer.Fields["InterpolationMethod"] != "None" {
+		for _, point := range attributeZ(xyPairs, mzPairs) {
+			xyzLineString.AddPoint(point.x, point.y, point.z*lengthFactor)
+		}
+	} else {
+		for _, pair := range xyPairs {
+			xyzLineString.AddPoint(pair[0], pair[1], 0.0)
 		}
 	}
 
 	xyzLineString.Transform(transform)
-	// This is a temporary fix since the x and y values need to be flipped
-	yxzLineString := flipXYLineString25D(xyzLineString)
 
-	multiLineString := yxzLineString.ForceToMultiLineString()
+	multiLineString := xyzLineString.ForceToMultiLineString()
+	defer multiLineString.Destroy()
 	wkb, err := multiLineString.ToWKB()
 	if err != nil {
-		return layer, xyPairs, mzPairs[0][0], err
+		return layer, xyPairs, mzPairs[0][0], stationScale, false, err
 	}
 	layer.Geometry = wkb
-	return layer, xyPairs, mzPairs[0][0], err
+	return layer, xyPairs, mzPairs[0][0], stationScale, true, nil
 }
 
-func getBanks(line string, transform gdal.CoordinateTransform, xsLayer VectorLayer, xyPairs [][2]float64, startingStation float64) ([]VectorLayer, error) {
+func getBanks(line string, transform gdal.CoordinateTransform, xsLayer VectorLayer, xyPairs [][2]float64, startingStation float64, stationScale float64) ([]VectorLayer, error) {
 	layers := []VectorLayer{}
 
 	bankStations := strings.Split(rightofEquals(line), ",")
@@ -357,17 +506,16 @@ func getBanks(line string, transform gdal.CoordinateTransform, xsLayer VectorLay
 		layer := VectorLayer{FeatureName: strings.TrimSpace(s), Fields: map[string]interface{}{}}
 		layer.Fields["RiverReachName"] = xsLayer.Fields["RiverReachName"]
 		layer.Fields["xsName"] = xsLayer.FeatureName
+		layer.Fields["InterpolationMethod"] = xsLayer.Fields["InterpolationMethod"]
 		bankStation, err := strconv.ParseFloat(s, 64)
 		if err != nil {
 			return layers, err
 		}
-		bankXY := interpXY(xyPairs, bankStation-startingStation)
+		bankXY := interpXY(xyPairs, (bankStation-startingStation)*stationScale)
 		xyPoint := gdal.Create(gdal.GT_Point)
 		xyPoint.AddPoint2D(bankXY[0], bankXY[1])
 		xyPoint.Transform(transform)
-		// This is a temporary fix since the x and y values need to be flipped
-		yxPoint := flipXYPoint(xyPoint)
-		multiPoint := yxPoint.ForceToMultiPoint()
+		multiPoint := xyPoint.ForceToMultiPoint()
 		wkb, err := multiPoint.ToWKB()
 		if err != nil {
 			return layers, err
@@ -378,27 +526,43 @@ func getBanks(line string, transform gdal.CoordinateTransform, xsLayer VectorLay
 	return layers, nil
 }
 
-func getStorageArea(sc *bufio.Scanner, transform gdal.CoordinateTransform) (VectorLayer, error) {
-	layer := VectorLayer{FeatureName: strings.TrimSpace(strings.Split(rightofEquals(sc.Text()), ",")[0])}
+// storageMethods are the ways a storage area's volume is computed, by "Storage Area Type="
+var storageMethods map[int]string = map[int]string{
+	0: "Area Times Depth",
+	1: "Elevation-Volume Curve",
+	2: "Terrain"}
+
+// polygonArea returns the area enclosed by a ring using the shoelace formula
+func polygonArea(xyPairs [][2]float64) float64 {
+	area := 0.0
+	for i := range xyPairs {
+		j := (i + 1) % len(xyPairs)
+		area += xyPairs[i][0]*xyPairs[j][1] - xyPairs[j][0]*xyPairs[i][1]
+	}
+	return math.Abs(area) / 2
+}
+
+// getStorageArea returns a storage area's polygon with the area it encloses in square feet or metres
+func getStorageArea(sc *bufio.Scanner, transform gdal.CoordinateTransform, lengthFactor float64) (VectorLayer, error) {
+	layer := VectorLayer{FeatureName: strings.TrimSpace(strings.Split(rightofEquals(sc.Text()), ",")[0]), Fields: map[string]interface{}{}}
 
 	xyPairs, err := getDataPairsfromTextBlock("Storage Area Surface Line=", sc, 32, 16)
 	if err != nil {
 		return layer, err
 	}
+	layer.Fields["PolygonArea"] = polygonArea(xyPairs) * lengthFactor * lengthFactor
 
 	xyLinearRing := gdal.Create(gdal.GT_LinearRing)
 	for _, pair := range xyPairs {
 		xyLinearRing.AddPoint2D(pair[0], pair[1])
 	}
 
-	xyLinearRing.Transform(transform)
-	// This is a temporary fix since the x and y values need to be flipped:
-	yxLinearRing := flipXYLinearRing(xyLinearRing)
+	xyPolygon := gdal.Create(gdal.GT_Polygon)
+	xyPolygon.AddGeometryDirectly(xyLinearRing)
+	xyPolygon.Transform(transform)
 
-	yxPolygon := gdal.Create(gdal.GT_Polygon)
-	yxPolygon.AddGeometry(yxLinearRing)
-	yxMultiPolygon := yxPolygon.ForceToMultiPolygon()
-	wkb, err := yxMultiPolygon.ToWKB()
+	xyMultiPolygon := xyPolygon.ForceToMultiPolygon()
+	wkb, err := xyMultiPolygon.ToWKB()
 	if err != nil {
 		return layer, err
 	}
@@ -406,11 +570,68 @@ func getStorageArea(sc *bufio.Scanner, transform gdal.CoordinateTransform) (Vect
 	return layer, err
 }
 
+// getStorageAreaAttribute adds the volume method, area, minimum elevation or elevation-volume curve given on a line
+// following a storage area's surface line to its fields. Areas and volumes are in acres and acre-feet for English
+// units and 1000 m2 and 1000 m3 for SI, as in the RAS storage area editor.
+func getStorageAreaAttribute(layer *VectorLayer, line string, sc *bufio.Scanner, lengthFactor float64, areaFactor float64, volumeFactor float64) error {
+	switch {
+	case strings.HasPrefix(line, "Storage Area Type="):
+		method, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+		if err != nil {
+			return err
+		}
+		layer.Fields["StorageMethod"] = storageMethods[method]
+		layer.Fields["AreaTimesDepth"] = method == 0
+		layer.Fields["TerrainBased"] = method == 2
+
+	case strings.HasPrefix(line, "Storage Area Area="):
+		area, err := stringtoFloat(rightofEquals(line))
+		if err != nil {
+			return err
+		}
+		layer.Fields["Area"] = area * areaFactor
+
+	case strings.HasPrefix(line, "Storage Area Min Elev="):
+		elev, err := stringtoFloat(rightofEquals(line))
+		if err != nil {
+			return err
+		}
+		layer.Fields["MinElevation"] = elev * lengthFactor
+
+	case strings.HasPrefix(line, "Storage Area Vol Elev="):
+		n, err := nDataPairs(line)
+		if err != nil {
+			return err
+		}
+		curve := [][2]float64{}
+		if n > 0 {
+			curve, err = dataPairsfromTextBlock(sc, n, 80, 8)
+			if err != nil {
+				return err
+			}
+		}
+		for i := range curve {
+			curve[i][0] *= lengthFactor
+			curve[i][1] *= volumeFactor
+		}
+		layer.Fields["ElevationVolume"] = curve
+	}
+	return nil
+}
+
 // GetGeospatialData ...
-func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string, sourceCRS string, destinationCRS int) error {
+func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string, sourceCRS string, destinationCRS int, scaleStations bool, modelUnits string, outputUnits string) error {
+	lengthFactor, err := unitFactor(modelUnits, outputUnits)
+	if err != nil {
+		return err
+	}
+	areaFactor, volumeFactor := storageFactors(modelUnits, outputUnits)
+
 	geomFileName := filepath.Base(geomFilePath)
 	f := Features{}
 	riverReachName := ""
+	storageArea := -1
+	withoutCutLines := 0
 	log.Println("Extracting geospatial data from:", geomFilePath)
 
 	file, err := fs.GetObject(geomFilePath)
@@ -425,10 +646,16 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 	if err != nil {
 		return err
 	}
+	defer transform.Destroy()
 
 	for sc.Scan() {
 		line := sc.Text()
 
+		// a storage area's attributes end at the next reach, storage area, structure or cross-section
+		if endsGeomBlock(line) {
+			storageArea = -1
+		}
+
 		switch {
 		case strings.HasPrefix(line, "River Reach="):
 			riverLayer, err := getRiverCenterline(sc, transform)
@@ -440,18 +667,38 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 			log.Println("Extracted river centerline")
 
 		case strings.HasPrefix(line, "Storage Area="):
-			storageAreaLayer, err := getStorageArea(sc, transform)
+			storageAreaLayer, err := getStorageArea(sc, transform, lengthFactor)
 			if err != nil {
 				return err
 			}
 			f.StorageAreas = append(f.StorageAreas, storageAreaLayer)
+			storageArea = len(f.StorageAreas) - 1
 			log.Println("Extracted storage area")
 
+		case storageArea >= 0 && strings.HasPrefix(line, "Storage Area "):
+			if err := getStorageAreaAttribute(&f.StorageAreas[storageArea], line, sc, lengthFactor, areaFactor, volumeFactor); err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "Pump Station="):
+			pumpLayer, ok, err := getPumpStation(line, transform)
+			if err != nil {
+				return err
+			}
+			if ok {
+				f.PumpStations = append(f.PumpStations, pumpLayer)
+				log.Println("Extracted pump station")
+			}
+
 		case strings.HasPrefix(line, "Type RM Length L Ch R = 1"):
-			xsLayer, bankLayers, err := getXSBanks(sc, transform, riverReachName)
+			xsLayer, bankLayers, ok, err := getXSBanks(sc, transform, riverReachName, scaleStations, lengthFactor)
 			if err != nil {
 				return err
 			}
+			if !ok {
+				withoutCutLines++
+				continue
+			}
 			f.XS = append(f.XS, xsLayer)
 			f.Banks = append(f.Banks, bankLayers...)
 			log.Println("Extracted banks and cross-sections")
@@ -459,6 +706,27 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 		}
 	}
 
+	if withoutCutLines > 0 {
+		msg := fmt.Sprintf("%d cross-sections of %s have no cut line and were not extracted", withoutCutLines, geomFileName)
+		log.Println(msg)
+		gd.Warnings = append(gd.Warnings, msg)
+	}
+
+	if err := getBankLines(&f); err != nil {
+		return err
+	}
+	log.Println("Extracted bank lines and channel polygons")
+
+	if err := getFlowPaths(&f, fs, geomFilePath, transform, lengthFactor); err != nil {
+		return err
+	}
+	log.Println("Extracted flow paths")
+
+	if err := getMeasuredCenterlines(&f, fs, geomFilePath, transform); err != nil {
+		return err
+	}
+	log.Println("Measured river centerlines in river stations")
+
 	gd.Features[geomFileName] = f
 	return nil
 }
@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"bufio"
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dewberry/gdal"
+)
+
+// epsgWKT returns the well known text of an EPSG code, the form in which model projections are read
+func epsgWKT(t *testing.T, epsg int) string {
+	t.Helper()
+	spRef := gdal.CreateSpatialReference("")
+	defer spRef.Destroy()
+	if err := spRef.FromEPSG(epsg); err != nil {
+		t.Fatal(err)
+	}
+	wkt, err := spRef.ToWKT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wkt
+}
+
+func TestGetTransform(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      int
+		destination int
+		x, y        float64
+		wantX       float64
+		wantY       float64
+		tolerance   float64
+	}{
+		{"geographic to web mercator", 4326, 3857, -95.3698, 29.7604, -10616517.57, 3472788.53, 0.01},
+		{"web mercator to geographic", 3857, 4326, 0, 1118889.97, 0, 10, 1e-6},
+		// NAD83 / Texas South Central (ftUS), compared within the accuracy of the NAD83 to WGS 84 transformation
+		{"state plane to geographic", 2278, 4326, 3120099.09, 13841900.86, -95.3698, 29.7604, 1e-5},
+		{"geographic to state plane", 4326, 2278, -95.3698, 29.7604, 3120099.09, 13841900.86, 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transform, err := getTransform(epsgWKT(t, tc.source), tc.destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer transform.Destroy()
+
+			x, y, z := []float64{tc.x}, []float64{tc.y}, []float64{0}
+			if !transform.Transform(1, x, y, z) {
+				t.Fatal("the point could not be transformed")
+			}
+			if math.Abs(x[0]-tc.wantX) > tc.tolerance || math.Abs(y[0]-tc.wantY) > tc.tolerance {
+				t.Errorf("got (%f, %f), want (%f, %f)", x[0], y[0], tc.wantX, tc.wantY)
+			}
+		})
+	}
+}
+
+func TestCheckAxisOrder(t *testing.T) {
+	if err := checkAxisOrder(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetStorageAreaAttribute(t *testing.T) {
+	acreFt := 43560 * usSurveyFoot * usSurveyFoot * usSurveyFoot / 1000
+	tests := []struct {
+		name     string
+		lines    []string
+		from, to string
+		field    string
+		want     interface{}
+	}{
+		{"volume method", []string{"Storage Area Type= 1"}, English, "", "StorageMethod", "Elevation-Volume Curve"},
+		{"area in acres", []string{"Storage Area Area=10"}, English, "", "Area", 10.0},
+		{"area in 1000 m2", []string{"Storage Area Area=10"}, English, SI, "Area", 10 * 43560 * usSurveyFoot * usSurveyFoot / 1000},
+		{"area in acres from 1000 m2", []string{"Storage Area Area=10"}, SI, English, "Area", 10000 / (43560 * usSurveyFoot * usSurveyFoot)},
+		{"minimum elevation", []string{"Storage Area Min Elev=100"}, English, SI, "MinElevation", 100 * usSurveyFoot},
+		{"elevation-volume curve in 1000 m3", []string{"Storage Area Vol Elev= 2 ", "     100       0     110      50"}, English, SI,
+			"ElevationVolume", [][2]float64{{100 * usSurveyFoot, 0}, {110 * usSurveyFoot, 50 * acreFt}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lengthFactor, err := unitFactor(tc.from, tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			areaFactor, volumeFactor := storageFactors(tc.from, tc.to)
+
+			sc := bufio.NewScanner(strings.NewReader(strings.Join(tc.lines[1:], "\n")))
+			layer := VectorLayer{Fields: map[string]interface{}{}}
+			if err := getStorageAreaAttribute(&layer, tc.lines[0], sc, lengthFactor, areaFactor, volumeFactor); err != nil {
+				t.Fatal(err)
+			}
+			if !approxEqual(layer.Fields[tc.field], tc.want) {
+				t.Errorf("got %v, want %v", layer.Fields[tc.field], tc.want)
+			}
+		})
+	}
+}
+
+// approxEqual compares numbers and curves within a relative tolerance and other values exactly
+func approxEqual(got, want interface{}) bool {
+	near := func(a, b float64) bool { return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b)) }
+	switch w := want.(type) {
+	case float64:
+		g, ok := got.(float64)
+		return ok && near(g, w)
+	case [][2]float64:
+		g, ok := got.([][2]float64)
+		if !ok || len(g) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !near(g[i][0], w[i][0]) || !near(g[i][1], w[i][1]) {
+				return false
+			}
+		}
+		return true
+	}
+	return got == want
+}
+
+func TestGetGeospatialDataPartialCutLines(t *testing.T) {
+	xs := func(station string, cutLine string) string {
+		block := "Type RM Length L Ch R = 1 ," + station + "     ,100,100,100\n"
+		if cutLine != "" {
+			block += "XS GIS Cut Line=2\n" + cutLine + "\n"
+		}
+		return block + "#Sta/Elev= 2\n       0     110     200     110\nBank Sta=50,150\n"
+	}
+	geom := `River Reach=Creek           ,Main
+Reach XY= 2
+               0            1000               0               0
+` + xs("500", "            -100             500             100             500") +
+		xs("450", "") +
+		xs("400", "            -100             400             100             400")
+
+	rm, dir := testModel(t, map[string]string{"Test.g01": geom})
+	gd := GeoData{Features: map[string]Features{}}
+	if err := GetGeospatialData(&gd, rm.FileStore, filepath.Join(dir, "Test.g01"), epsgWKT(t, 26915), 26915, false, English, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	f := gd.Features["Test.g01"]
+	names := []string{}
+	for _, layer := range f.XS {
+		names = append(names, layer.FeatureName)
+	}
+	if !reflect.DeepEqual(names, []string{"500", "400"}) {
+		t.Errorf("got cross-sections %v, want 500 and 400", names)
+	}
+	if len(f.Banks) != 4 {
+		t.Errorf("got %d banks, want 4", len(f.Banks))
+	}
+	if len(gd.Warnings) != 1 {
+		t.Errorf("got warnings %v, want one for the cross-section without a cut line", gd.Warnings)
+	}
+
+	// the cross-section at 400 keeps its own cut line
+	geometry, err := gdal.CreateFromWKB(f.XS[1].Geometry, gdal.SpatialReference{}, len(f.XS[1].Geometry))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer geometry.Destroy()
+	if env := geometry.Envelope(); env.MinY() != 400 || env.MaxY() != 400 {
+		t.Errorf("got a cut line from y %v to %v, want 400", env.MinY(), env.MaxY())
+	}
+}
+
+func TestMatchProfile(t *testing.T) {
+	profile := [][2]float64{{10, 110}, {110, 100}, {210, 110}}
+	tests := []struct {
+		name          string
+		lenCutLine    float64
+		scaleStations bool
+		method        string
+		ratio         interface{}
+		want          [][2]float64
+		scale         float64
+	}{
+		{"matching lengths", 200.05, true, "Exact", 200.05 / 200, profile, 1},
+		{"mismatched lengths without scaling", 300, false, "None", 1.5, profile, 1},
+		{"mismatched lengths scaled", 300, true, "Proportional", 1.5, [][2]float64{{10, 110}, {160, 100}, {310, 110}}, 1.5},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			layer := VectorLayer{Fields: map[string]interface{}{"InterpolationMethod": "None"}}
+			got, scale := matchProfile(&layer, profile, tc.lenCutLine, tc.scaleStations)
+			if layer.Fields["InterpolationMethod"] != tc.method || !approxEqual(layer.Fields["LengthMismatchRatio"], tc.ratio) {
+				t.Errorf("got method %v and ratio %v, want %v and %v", layer.Fields["InterpolationMethod"],
+					layer.Fields["LengthMismatchRatio"], tc.method, tc.ratio)
+			}
+			if !approxEqual(got, tc.want) || scale != tc.scale {
+				t.Errorf("got profile %v with scale %v, want %v with scale %v", got, scale, tc.want, tc.scale)
+			}
+		})
+	}
+}
+
+func TestScaledStationsAlongCutLine(t *testing.T) {
+	// a 300 long cut line with a 200 long profile starting at station 10
+	cutLine := [][2]float64{{100, 500}, {250, 500}, {250, 650}}
+	layer := VectorLayer{Fields: map[string]interface{}{"InterpolationMethod": "None"}}
+	profile, scale := matchProfile(&layer, [][2]float64{{10, 110}, {110, 100}, {210, 110}}, polylineLength(cutLine), true)
+
+	got := attributeZ(cutLine, profile)
+	want := []xyzPoint{{100, 500, 110}, {250, 500, 100}, {250, 650, 110}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// a bank station halfway between the first two profile points lands halfway along the first segment
+	if bank := interpXY(cutLine, (60-profile[0][0])*scale); bank != [2]float64{175, 500} {
+		t.Errorf("got bank at %v, want (175, 500)", bank)
+	}
+}
@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/USACE/filestore"
+	"github.com/USACE/mcat-ras/hdf"
+	"github.com/dewberry/gdal"
+)
+
+// gdalPath returns the path from which gdal reads a file in place, the file itself in a local file store or its
+// /vsis3/ path in the S3 bucket. Files in other file stores must be copied.
+func gdalPath(fs filestore.FileStore, fn string) string {
+	switch fs.(type) {
+	case *filestore.BlockFS:
+		return fn
+	case *filestore.S3FS:
+		if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
+			return "/vsis3/" + bucket + "/" + strings.TrimPrefix(fn, "/")
+		}
+	}
+	return ""
+}
+
+// hdfLocalFile copies an HDF file or raster from the FileStore to a temporary file so that it can be opened by gdal.
+// The caller is responsible for removing the temporary file.
+func hdfLocalFile(fs filestore.FileStore, fn string) (string, error) {
+	f, err := fs.GetObject(fn)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	tmp, err := ioutil.TempFile("", "*"+filepath.Ext(fn))
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, f); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return tmp.Name(), nil
+}
+
+// getHDFAttribute reads an attribute from the root group of an HDF file, copying the file only when gdal cannot read
+// it in place
+func getHDFAttribute(fs filestore.FileStore, fn string, name string) (string, error) {
+	if path := gdalPath(fs, fn); path != "" {
+		if ds, err := gdal.Open(path, gdal.ReadOnly); err == nil {
+			defer ds.Close()
+			return ds.MetadataItem(name, ""), nil
+		}
+	}
+
+	local, err := hdfLocalFile(fs, fn)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(local)
+
+	ds, err := gdal.Open(local, gdal.ReadOnly)
+	if err != nil {
+		return "", err
+	}
+	defer ds.Close()
+
+	return ds.MetadataItem(name, ""), nil
+}
+
+// hdfFile is an HDF file opened from the FileStore, with the path of its local copy when it could not be read in place
+type hdfFile struct {
+	*hdf.File
+	local string
+}
+
+// openHDF opens an HDF file in place, copying it only when gdal cannot read it from the FileStore, e.g. when the
+// S3 bucket is not configured or gdal was built without /vsis3/ support for HDF5. The file must be closed to remove
+// any copy.
+func openHDF(fs filestore.FileStore, fn string) (*hdfFile, error) {
+	if path := gdalPath(fs, fn); path != "" {
+		f, err := hdf.Open(path)
+		if err == nil {
+			return &hdfFile{File: f}, nil
+		}
+		if path == fn {
+			return nil, err
+		}
+	}
+
+	local, err := hdfLocalFile(fs, fn)
+	if err != nil {
+		return nil, err
+	}
+	f, err := hdf.Open(local)
+	if err != nil {
+		os.Remove(local)
+		return nil, fmt.Errorf("%s could not be opened: %s", filepath.Base(fn), err)
+	}
+	return &hdfFile{File: f, local: local}, nil
+}
+
+// Close releases the file and removes its local copy
+func (h *hdfFile) Close() {
+	h.File.Close()
+	if h.local != "" {
+		os.Remove(h.local)
+	}
+}
+
+// hdfReader reads the datasets of an HDF file. It is implemented by hdfFile and lets the readers of the plan and
+// geometry HDF layouts be tested without HDF files.
+type hdfReader interface {
+	ReadFloats(path string) ([]float64, []int, error)
+	ReadStrings(path string, field string) ([]string, error)
+}
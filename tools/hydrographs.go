@@ -0,0 +1,207 @@
+package tools
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/USACE/mcat-ras/hdf"
+)
+
+// Hydrograph is a stage or flow time series of an unsteady plan at a cross-section, storage area or 2D reference
+// line or point. Times are in days from the start of the simulation.
+type Hydrograph struct {
+	Plan         string
+	LocationType string `json:"Location Type"`
+	Location     string
+	Variable     string
+	Times        []float64 `json:"Times"`
+	Values       []float64 `json:"Values"`
+}
+
+// hydrographLocations are the locations of a type with results in a plan HDF group, in the order of its columns
+type hydrographLocations struct {
+	locationType string
+	group        string
+	names        []string
+	datasets     map[string]string
+}
+
+// hydrographVariables are the variables that can be extracted and the power of the length factor that converts them
+var hydrographVariables = map[string]int{"stage": 1, "flow": 3}
+
+// readLocationNames reads the names of the storage areas or reference lines or points of a plan's geometry. Plans
+// without locations of the type have no attributes table.
+func readLocationNames(h hdfReader, path string) ([]string, error) {
+	names, err := h.ReadStrings(path, "Name")
+	if errors.Is(err, hdf.ErrNotFound) {
+		return []string{}, nil
+	}
+	return names, err
+}
+
+// getHydrographLocations lists the cross-sections, storage areas and reference lines and points of a plan in the
+// order of the columns of its results, read from the geometry attributes of the plan HDF file. Plans without
+// cross-sections, such as 2D only plans, have no cross-section attributes.
+func getHydrographLocations(h hdfReader) ([]hydrographLocations, error) {
+	locations := []hydrographLocations{}
+
+	attributes, err := readXSAttributes(h, false)
+	if err != nil && !errors.Is(err, hdf.ErrNotFound) {
+		return locations, err
+	}
+	xs := hydrographLocations{"Cross Section", unsteadyTimeSeries + "Cross Sections/", []string{},
+		map[string]string{"stage": "Water Surface", "flow": "Flow"}}
+	for _, a := range attributes {
+		xs.names = append(xs.names, fmt.Sprintf("%s, %s, %s", a[0], a[1], a[2]))
+	}
+	locations = append(locations, xs)
+
+	for _, l := range []struct {
+		locations  hydrographLocations
+		attributes string
+	}{
+		{hydrographLocations{"Storage Area", unsteadyTimeSeries + "Storage Areas/", nil,
+			map[string]string{"stage": "Water Surface", "flow": "Flow"}}, "/Geometry/Storage Areas/Attributes"},
+		{hydrographLocations{"Reference Line", unsteadyTimeSeries + "Reference Lines/", nil,
+			map[string]string{"stage": "Water Surface", "flow": "Flow"}}, "/Geometry/Reference Lines/Attributes"},
+		{hydrographLocations{"Reference Point", unsteadyTimeSeries + "Reference Points/", nil,
+			map[string]string{"stage": "Water Surface"}}, "/Geometry/Reference Points/Attributes"},
+	} {
+		l.locations.names, err = readLocationNames(h, l.attributes)
+		if err != nil {
+			return locations, err
+		}
+		locations = append(locations, l.locations)
+	}
+	return locations, nil
+}
+
+// getHydrographVariables returns the variables to extract, both stage and flow when variable is empty
+func getHydrographVariables(variable string) ([]string, error) {
+	if variable == "" {
+		return []string{"stage", "flow"}, nil
+	}
+	if _, ok := hydrographVariables[variable]; !ok {
+		return nil, fmt.Errorf("%s is not a valid variable, use stage or flow", variable)
+	}
+	return []string{variable}, nil
+}
+
+// Hydrographs extracts the stage or flow time series of an unsteady plan, in the model's output units. The plan is
+// given by file name or extension. A location filters the results to the cross-section ("River, Reach, Station"),
+// storage area or reference line or point of that name, and an empty variable returns both stage and flow.
+func (rm *RasModel) Hydrographs(planFile string, location string, variable string) ([]Hydrograph, error) {
+	hydrographs := []Hydrograph{}
+
+	variables, err := getHydrographVariables(variable)
+	if err != nil {
+		return hydrographs, err
+	}
+
+	pr, err := rm.getPlanResults(planFile)
+	if err != nil {
+		return hydrographs, err
+	}
+	if pr.Steady {
+		return hydrographs, fmt.Errorf("%s is a steady plan, hydrographs are only available for unsteady plans", planFile)
+	}
+
+	lengthFactor, err := rm.lengthFactor()
+	if err != nil {
+		return hydrographs, err
+	}
+
+	paths := []string{unsteadyTimeSeries + "Time"}
+	for _, group := range []string{"Cross Sections/", "Storage Areas/", "Reference Lines/", "Reference Points/"} {
+		paths = append(paths, unsteadyTimeSeries+group+"Water Surface", unsteadyTimeSeries+group+"Flow")
+	}
+	f, err := openHDF(rm.FileStore, pr.HDFFile)
+	if err != nil {
+		return hydrographs, err
+	}
+	defer f.Close()
+	results, err := readResults(f, paths...)
+	if err != nil {
+		return hydrographs, err
+	}
+
+	locations, err := getHydrographLocations(f)
+	if err != nil {
+		return hydrographs, err
+	}
+
+	// the time dataset is one dimensional, read as a single row
+	timeRows, ok := results[unsteadyTimeSeries+"Time"]
+	if !ok || len(timeRows) == 0 {
+		return hydrographs, fmt.Errorf("the time steps were not found in the results of %s", filepath.Base(pr.Plan.Path))
+	}
+	times := timeRows[0]
+
+	for _, locs := range locations {
+		for _, v := range variables {
+			dataset, ok := locs.datasets[v]
+			if !ok {
+				continue
+			}
+			rows, ok := results[locs.group+dataset]
+			if !ok {
+				continue
+			}
+			if len(rows) > 0 && len(rows[0]) != len(locs.names) {
+				return hydrographs, fmt.Errorf("the %s results of %s do not match its %d %s attributes", strings.ToLower(locs.locationType),
+					filepath.Base(pr.Plan.Path), len(locs.names), strings.ToLower(locs.locationType))
+			}
+			if len(rows) > len(times) {
+				return hydrographs, fmt.Errorf("the %s results of %s have %d time steps but only %d times", strings.ToLower(locs.locationType),
+					filepath.Base(pr.Plan.Path), len(rows), len(times))
+			}
+
+			f := 1.0
+			for p := 0; p < hydrographVariables[v]; p++ {
+				f *= lengthFactor
+			}
+
+			for col, name := range locs.names {
+				if location != "" && name != location {
+					continue
+				}
+				h := Hydrograph{Plan: filepath.Base(pr.Plan.Path), LocationType: locs.locationType, Location: name, Variable: v,
+					Times: make([]float64, 0, len(rows)), Values: make([]float64, 0, len(rows))}
+				for t, row := range rows {
+					h.Times = append(h.Times, times[t])
+					h.Values = append(h.Values, row[col]*f)
+				}
+				hydrographs = append(hydrographs, h)
+			}
+		}
+	}
+
+	if location != "" && len(hydrographs) == 0 {
+		return hydrographs, fmt.Errorf("no %s results were found for %s", strings.Join(variables, " or "), location)
+	}
+	return hydrographs, nil
+}
+
+// WriteHydrographsCSV writes one row per time step of each hydrograph
+func WriteHydrographsCSV(w io.Writer, hydrographs []Hydrograph) error {
+	cw := csv.NewWriter(w)
+	header := []string{"plan", "location_type", "location", "variable", "time_days", "value"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, h := range hydrographs {
+		for i, t := range h.Times {
+			row := []string{h.Plan, h.LocationType, h.Location, h.Variable, formatFloat(t), formatFloat(h.Values[i])}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestGetHydrographVariables(t *testing.T) {
+	testCases := []struct {
+		variable string
+		want     []string
+		err      bool
+	}{
+		{"", []string{"stage", "flow"}, false},
+		{"stage", []string{"stage"}, false},
+		{"flow", []string{"flow"}, false},
+		{"velocity", nil, true},
+		{"Stage", nil, true},
+	}
+
+	for _, tc := range testCases {
+		got, err := getHydrographVariables(tc.variable)
+		if (err != nil) != tc.err || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: got (%v, %v), want %v with an error %v", tc.variable, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestGetHydrographLocations(t *testing.T) {
+	xsAttributes := unsteadyGeometryInfo + "Cross Section Attributes"
+	testCases := []struct {
+		name  string
+		texts map[string][]string
+		want  map[string][]string
+	}{
+		{"1D and 2D plan", map[string][]string{
+			xsAttributes + "|River":                      {"Creek", "Creek"},
+			xsAttributes + "|Reach":                      {"Main", "Main"},
+			xsAttributes + "|Station":                    {"2000", "1000.5"},
+			"/Geometry/Storage Areas/Attributes|Name":    {"Pond"},
+			"/Geometry/Reference Lines/Attributes|Name":  {"Bridge", "Culvert"},
+			"/Geometry/Reference Points/Attributes|Name": {"Gauge"},
+		}, map[string][]string{
+			"Cross Section":   {"Creek, Main, 2000", "Creek, Main, 1000.5"},
+			"Storage Area":    {"Pond"},
+			"Reference Line":  {"Bridge", "Culvert"},
+			"Reference Point": {"Gauge"},
+		}},
+		{"2D only plan", map[string][]string{
+			"/Geometry/Reference Lines/Attributes|Name": {"Bridge"},
+		}, map[string][]string{
+			"Cross Section":   {},
+			"Storage Area":    {},
+			"Reference Line":  {"Bridge"},
+			"Reference Point": {},
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			locations, err := getHydrographLocations(memHDF{texts: tc.texts})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := map[string][]string{}
+			for _, l := range locations {
+				got[l.locationType] = l.names
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// reference points only have stage results
+	locations, err := getHydrographLocations(memHDF{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range locations {
+		if _, ok := l.datasets["flow"]; ok == (l.locationType == "Reference Point") {
+			t.Errorf("%s: flow results %v", l.locationType, ok)
+		}
+	}
+}
+
+func TestWriteHydrographsCSV(t *testing.T) {
+	hydrographs := []Hydrograph{
+		{Plan: "Test.p01", LocationType: "Cross Section", Location: "Creek, Main, 1500", Variable: "stage",
+			Times: []float64{0, 0.25}, Values: []float64{100.5, 101}},
+		{Plan: "Test.p01", LocationType: "Reference Line", Location: "Bridge", Variable: "flow",
+			Times: []float64{0}, Values: []float64{250}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHydrographsCSV(&buf, hydrographs); err != nil {
+		t.Fatal(err)
+	}
+	want := `plan,location_type,location,variable,time_days,value
+Test.p01,Cross Section,"Creek, Main, 1500",stage,0,100.5
+Test.p01,Cross Section,"Creek, Main, 1500",stage,0.25,101
+Test.p01,Reference Line,Bridge,flow,0,250
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
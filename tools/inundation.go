@@ -0,0 +1,214 @@
+package tools
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"path/filepath"
+
+	"github.com/dewberry/gdal"
+)
+
+// wetLimits returns the stations where a water surface meets a cross-section's station-elevation profile on either
+// side of its lowest point. When the water surface is above the end of the profile, the end station is returned. A
+// levee on the wet side of the lowest point holds the water at its station until the water surface overtops it.
+// Ineffective flow areas do not limit the wet extent, since they hold water that does not convey flow.
+func wetLimits(profile [][2]float64, levees [2][]float64, wse float64) (float64, float64, bool) {
+	if len(profile) < 2 {
+		return 0, 0, false
+	}
+	low := 0
+	for i, p := range profile {
+		if p[1] < profile[low][1] {
+			low = i
+		}
+	}
+	if profile[low][1] >= wse {
+		return 0, 0, false
+	}
+
+	crossing := func(p0, p1 [2]float64) float64 {
+		return p0[0] + (wse-p0[1])/(p1[1]-p0[1])*(p1[0]-p0[0])
+	}
+
+	l := low
+	for l > 0 && profile[l-1][1] < wse {
+		l--
+	}
+	left := profile[0][0]
+	if l > 0 {
+		left = crossing(profile[l], profile[l-1])
+	}
+
+	r := low
+	for r < len(profile)-1 && profile[r+1][1] < wse {
+		r++
+	}
+	right := profile[len(profile)-1][0]
+	if r < len(profile)-1 {
+		right = crossing(profile[r], profile[r+1])
+	}
+
+	thalweg := profile[low][0]
+	if levee := levees[0]; len(levee) == 2 && levee[0] > left && levee[0] < thalweg && wse < levee[1] {
+		left = levee[0]
+	}
+	if levee := levees[1]; len(levee) == 2 && levee[0] < right && levee[0] > thalweg && wse < levee[1] {
+		right = levee[0]
+	}
+	return left, right, true
+}
+
+// inundationRing connects the left wet limits of a reach's cross-sections from upstream to downstream with the right
+// wet limits from downstream to upstream. Cross-sections without a water surface or cut line are skipped.
+func inundationRing(reach reachGeometry, wse []float64) [][2]float64 {
+	lefts, rights := [][2]float64{}, [][2]float64{}
+	for i, xs := range reach.XS {
+		if !xs.hasGIS() || math.IsNaN(wse[i]) {
+			continue
+		}
+		left, right, ok := wetLimits(xs.Profile, xs.Levees, wse[i])
+		if !ok {
+			continue
+		}
+		lefts = append(lefts, xs.stationXY(left))
+		rights = append(rights, xs.stationXY(right))
+	}
+	if len(lefts) < 2 {
+		return nil
+	}
+
+	ring := lefts
+	for i := len(rights) - 1; i >= 0; i-- {
+		ring = append(ring, rights[i])
+	}
+	return append(ring, lefts[0])
+}
+
+// getInundationBoundaries returns an inundation boundary polygon per reach for the cross-section water surface
+// elevations of each reach
+func getInundationBoundaries(reaches []reachGeometry, wse [][]float64, plan string, profile string, transform gdal.CoordinateTransform) ([]VectorLayer, error) {
+	layers := []VectorLayer{}
+	for r, reach := range reaches {
+		ring := inundationRing(reach, wse[r])
+		if ring == nil {
+			continue
+		}
+
+		layer := VectorLayer{FeatureName: fmt.Sprintf("%s %s", reach.name(), profile), Fields: map[string]interface{}{}}
+		layer.Fields["RiverReachName"] = reach.name()
+		layer.Fields["Plan"] = plan
+		layer.Fields["Profile"] = profile
+
+		xyPolygon := ringPolygon(ring)
+		// buffer by zero to repair boundaries whose wet limits cross
+		repaired := xyPolygon.Buffer(0, 8)
+		xyPolygon.Destroy()
+		repaired.Transform(transform)
+
+		xyMultiPolygon := repaired.ForceToMultiPolygon()
+		wkb, err := xyMultiPolygon.ToWKB()
+		xyMultiPolygon.Destroy()
+		if err != nil {
+			return layers, err
+		}
+		layer.Geometry = wkb
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+// planInundationBoundaries returns the inundation boundaries of a plan for each steady profile or the maximum water
+// surface of an unsteady plan
+func (rm *RasModel) planInundationBoundaries(pr planResults, reaches []reachGeometry, transform gdal.CoordinateTransform) ([]VectorLayer, error) {
+	layers := []VectorLayer{}
+	planFile := filepath.Base(pr.Plan.Path)
+
+	h, err := openHDF(rm.FileStore, pr.HDFFile)
+	if err != nil {
+		return layers, err
+	}
+	defer h.Close()
+
+	group, dataset := unsteadyXSSummary, "Maximum Water Surface"
+	profiles := []string{"Max WS"}
+	if pr.Steady {
+		group, dataset = steadyXSResults, "Water Surface"
+		profiles, err = readProfileNames(h, pr)
+		if err != nil {
+			return layers, err
+		}
+	}
+	results, err := readResults(h, group+dataset)
+	if err != nil {
+		return layers, err
+	}
+	rows, ok := results[group+dataset]
+	if !ok {
+		return layers, fmt.Errorf("%s was not found in the results of %s", dataset, planFile)
+	}
+	if !pr.Steady && len(rows) > 1 {
+		// the summary's second row is the time of the maximum
+		rows = rows[:1]
+	}
+	columns, err := readXSColumns(h, pr.Steady)
+	if err != nil {
+		return layers, err
+	}
+
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return layers, fmt.Errorf("the results of %s do not match its %d cross-section attributes", planFile, len(columns))
+		}
+		boundaries, err := getInundationBoundaries(reaches, joinXSResults(reaches, columns, row), planFile, profileName(profiles, i), transform)
+		if err != nil {
+			return layers, err
+		}
+		layers = append(layers, boundaries...)
+	}
+	return layers, nil
+}
+
+// InundationBoundaries adds the inundation boundaries of each plan with results to the geospatial data, keyed by
+// plan file. Steady plans have a boundary per profile and unsteady plans a boundary of the maximum water surface.
+// The water surface of each cross-section is intersected with its station-elevation profile, held by levees that it
+// does not overtop, and the wet limits are connected along each reach. The boundaries are an approximation: the
+// terrain between cross-sections is ignored, ineffective flow areas are included and low ground beyond the first
+// point above the water surface on either side of the lowest point is treated as dry. Plans whose geometry or
+// results cannot be read are reported as warnings.
+func (rm *RasModel) InundationBoundaries(gd *GeoData, destinationCRS int) error {
+	gd.Inundation = map[string][]VectorLayer{}
+
+	transform, err := getTransform(rm.Metadata.Projection, destinationCRS)
+	if err != nil {
+		return err
+	}
+	defer transform.Destroy()
+
+	warn := func(err error) {
+		log.Println(err)
+		gd.Warnings = append(gd.Warnings, err.Error())
+	}
+
+	for _, p := range rm.Metadata.PlanFiles {
+		pr, err := rm.getPlanResults(filepath.Base(p.Path))
+		if err != nil {
+			warn(err)
+			continue
+		}
+
+		reaches, err := readXSGeometry(rm.FileStore, pr.Geom.Path)
+		if err != nil {
+			warn(err)
+			continue
+		}
+
+		layers, err := rm.planInundationBoundaries(pr, reaches, transform)
+		if err != nil {
+			warn(err)
+			continue
+		}
+		gd.Inundation[filepath.Base(p.Path)] = layers
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestWetLimits(t *testing.T) {
+	valley := [][2]float64{{0, 10}, {10, 0}, {20, 10}}
+	tests := []struct {
+		name        string
+		profile     [][2]float64
+		levees      [2][]float64
+		wse         float64
+		left, right float64
+		wet         bool
+	}{
+		{"within the banks", valley, [2][]float64{}, 5, 5, 15, true},
+		{"above the ends of the profile", valley, [2][]float64{}, 12, 0, 20, true},
+		{"below the thalweg", valley, [2][]float64{}, -1, 0, 0, false},
+		{"island above the water surface", [][2]float64{{0, 10}, {10, 0}, {20, 8}, {30, 0}, {40, 10}}, [2][]float64{}, 5, 5, 16.25, true},
+		{"single point", [][2]float64{{0, 0}}, [2][]float64{}, 5, 0, 0, false},
+		{"held by levees", valley, [2][]float64{{7, 8}, {14, 6}}, 5.5, 7, 14, true},
+		{"overtopping the right levee", valley, [2][]float64{{7, 8}, {14, 6}}, 7, 7, 17, true},
+		{"levees beyond the water", valley, [2][]float64{{2, 12}, {18, 12}}, 5, 5, 15, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			left, right, wet := wetLimits(tc.profile, tc.levees, tc.wse)
+			if left != tc.left || right != tc.right || wet != tc.wet {
+				t.Errorf("got (%v, %v, %v), want (%v, %v, %v)", left, right, wet, tc.left, tc.right, tc.wet)
+			}
+		})
+	}
+}
+
+func TestInundationRing(t *testing.T) {
+	valley := [][2]float64{{0, 10}, {10, 0}, {20, 10}}
+	reach := reachGeometry{River: "Creek", Reach: "Main", XS: []xsGeometry{
+		{Name: "200", CutLine: [][2]float64{{0, 100}, {20, 100}}, Profile: valley},
+		{Name: "150", Profile: valley},
+		{Name: "100", CutLine: [][2]float64{{0, 0}, {20, 0}}, Profile: valley},
+	}}
+
+	tests := []struct {
+		name string
+		wse  []float64
+		want [][2]float64
+	}{
+		{"cross-section without a cut line skipped", []float64{5, 5, 5}, [][2]float64{{5, 100}, {5, 0}, {15, 0}, {15, 100}, {5, 100}}},
+		{"cross-section without results", []float64{5, 5, math.NaN()}, nil},
+		{"dry cross-section", []float64{5, 5, -1}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := inundationRing(reach, tc.wse); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"path/filepath"
+	"strconv"
+)
+
+// StructureRecord is a row of the structure inventory. Width, height and length are the structure's key dimensions:
+// the deck or weir width of culverts, bridges and weirs, and the span, rise and length of culvert conduits and gates.
+// Low and high elevations are the low and high chords, weir crest, conduit inverts, gate sill and top of gate, or the
+// range of an outlet's rating curve. Count is the number of conduits, piers, gates, barrels, openings or pumps. Pump
+// stations and rating curve outlets are placed at the river station they connect to on their reach and have no river
+// station when they only connect storage areas.
+type StructureRecord struct {
+	GeomFile      string
+	River         string
+	Reach         string
+	RiverStation  string `json:"River Station"`
+	Type          string
+	Name          string
+	Structure     string
+	Shape         string
+	Width         float64
+	Height        float64
+	Length        float64
+	LowElevation  float64 `json:"Low Elevation"`
+	HighElevation float64 `json:"High Elevation"`
+	Count         int
+}
+
+func conduitRecords(base StructureRecord, structure string, cs []conduits) []StructureRecord {
+	records := []StructureRecord{}
+	for _, c := range cs {
+		r := StructureRecord{GeomFile: base.GeomFile, River: base.River, Reach: base.Reach, RiverStation: base.RiverStation}
+		r.Type, r.Name, r.Structure, r.Shape = "Culvert Conduit", c.Name, structure, c.Shape
+		r.Width, r.Height, r.Length = c.Span, c.Rise, c.Length
+		r.LowElevation, r.HighElevation = math.Min(c.UpInvert, c.DownInvert), math.Max(c.UpInvert, c.DownInvert)
+		r.Count = c.NumBarrels
+		records = append(records, r)
+	}
+	return records
+}
+
+func gateRecords(base StructureRecord, structure string, gs []gates) []StructureRecord {
+	records := []StructureRecord{}
+	for _, g := range gs {
+		r := StructureRecord{GeomFile: base.GeomFile, River: base.River, Reach: base.Reach, RiverStation: base.RiverStation}
+		r.Type, r.Name, r.Structure, r.Shape = "Gate", g.Name, structure, g.Type
+		r.Width, r.Height = g.Width, g.Height
+		r.LowElevation, r.HighElevation = g.SillElevation, g.SillElevation+g.Height
+		r.Count = g.NumOpenings
+		records = append(records, r)
+	}
+	return records
+}
+
+// structureRecords flattens the structures of a reach into inventory rows. Conduits and gates follow the structure
+// they belong to.
+func structureRecords(geomFile string, hs hydraulicStructures) []StructureRecord {
+	records := []StructureRecord{}
+	reach := StructureRecord{GeomFile: geomFile, River: hs.River, Reach: hs.Reach}
+
+	for _, c := range hs.CulvertData.Culverts {
+		r := reach.withStation(c.Station)
+		r.Type, r.Name, r.Width = "Culvert", c.Name, c.DeckWidth
+		r.LowElevation, r.HighElevation = c.UpLowChord.Min, c.UpHighChord.Max
+		r.Count = c.NumConduits
+		records = append(records, r)
+		records = append(records, conduitRecords(r, r.Name, c.Conduits)...)
+	}
+	for _, b := range hs.BridgeData.Bridges {
+		r := reach.withStation(b.Station)
+		r.Type, r.Name, r.Width = "Bridge", b.Name, b.DeckWidth
+		r.LowElevation, r.HighElevation = b.UpLowChord.Min, b.UpHighChord.Max
+		r.Count = b.NumPiers
+		records = append(records, r)
+	}
+	for _, w := range hs.WeirData.Weirs {
+		r := reach.withStation(w.Station)
+		r.Type, r.Name, r.Width = "Inline Weir", w.Name, w.WeirWidth
+		r.LowElevation, r.HighElevation = w.WeirElev.Min, w.WeirElev.Max
+		r.Count = w.NumGates
+		records = append(records, r)
+		records = append(records, gateRecords(r, r.Name, w.Gates)...)
+		records = append(records, conduitRecords(r, r.Name, w.Conduits)...)
+	}
+	for _, l := range hs.LateralData.LateralStructures {
+		r := reach.withStation(l.Station)
+		r.Type, r.Name, r.Width = "Lateral Structure", l.Name, l.WeirWidth
+		r.LowElevation, r.HighElevation = l.WeirElev.Min, l.WeirElev.Max
+		r.Count = l.NumGates
+		records = append(records, r)
+		records = append(records, gateRecords(r, r.Name, l.Gates)...)
+		records = append(records, conduitRecords(r, r.Name, l.Conduits)...)
+	}
+	for _, p := range hs.PumpData.PumpStations {
+		r := reach.withConnection(p.From, p.To)
+		r.Type, r.Name = "Pump Station", p.Name
+		for _, g := range p.Groups {
+			r.Count += len(g.Pumps)
+		}
+		records = append(records, r)
+	}
+	for _, o := range hs.OutletData.Outlets {
+		r := reach.withConnection(o.From, o.To)
+		r.Type, r.Name = "Rating Curve Outlet", o.Name
+		if len(o.RatingCurve) > 0 {
+			r.LowElevation, r.HighElevation = o.RatingCurve[0][0], o.RatingCurve[0][0]
+			for _, point := range o.RatingCurve {
+				r.LowElevation, r.HighElevation = math.Min(r.LowElevation, point[0]), math.Max(r.HighElevation, point[0])
+			}
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// withConnection sets the river station of a pump station or outlet from the end connected to the record's reach
+func (r StructureRecord) withConnection(from, to connection) StructureRecord {
+	for _, c := range []connection{from, to} {
+		if c.RiverStation != "" && c.River == r.River && c.Reach == r.Reach {
+			r.RiverStation = c.RiverStation
+			break
+		}
+	}
+	return r
+}
+
+func (r StructureRecord) withStation(station float64) StructureRecord {
+	r.RiverStation = formatFloat(station)
+	return r
+}
+
+// StructureInventory lists the culverts, bridges, inline weirs, lateral structures, gates, culvert conduits, pump
+// stations and rating curve outlets of every geometry file in one table, in the model's output units
+func (rm *RasModel) StructureInventory() []StructureRecord {
+	records := []StructureRecord{}
+	for _, g := range rm.Metadata.GeomFiles {
+		for _, hs := range g.Structures {
+			records = append(records, structureRecords(filepath.Base(g.Path), hs)...)
+		}
+	}
+	return records
+}
+
+// WriteStructuresCSV writes one row per structure record
+func WriteStructuresCSV(w io.Writer, records []StructureRecord) error {
+	cw := csv.NewWriter(w)
+	header := []string{"geom_file", "river", "reach", "river_station", "type", "name", "structure", "shape",
+		"width", "height", "length", "low_elevation", "high_elevation", "count"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		row := []string{r.GeomFile, r.River, r.Reach, r.RiverStation, r.Type, r.Name, r.Structure, r.Shape,
+			formatFloat(r.Width), formatFloat(r.Height), formatFloat(r.Length), formatFloat(r.LowElevation),
+			formatFloat(r.HighElevation), strconv.Itoa(r.Count)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
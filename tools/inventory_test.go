@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteStructuresCSV(t *testing.T) {
+	hs := hydraulicStructures{River: "Creek", Reach: "Main",
+		WeirData: weirData{NumWeirs: 1, Weirs: []weirs{{Name: "Dam", Station: 450, WeirWidth: 20,
+			WeirElev: maxMinPairs{Min: 100, Max: 105.5}, NumGates: 1,
+			Gates: []gates{{Name: "Gate #1", Type: "Sluice", Width: 10, Height: 5, SillElevation: 95, NumOpenings: 2}}}}},
+		PumpData: pumpStationData{NumPumpStations: 1, PumpStations: []pumpStations{{Name: "Lift",
+			From: connection{StorageArea: "Pond"}, To: connection{River: "Creek", Reach: "Main", RiverStation: "420"},
+			Groups: []pumpGroups{{Pumps: []pumps{{}, {}}}, {Pumps: []pumps{{}}}}}}},
+		OutletData: outletData{NumOutlets: 1, Outlets: []outlets{{Name: "Spillway",
+			From: connection{River: "Creek", Reach: "Main", RiverStation: "480"}, To: connection{StorageArea: "Pond"},
+			RatingCurve: [][2]float64{{102, 0}, {101, 0}, {104.5, 300}}}}},
+	}
+	storageAreas := hydraulicStructures{OutletData: outletData{NumOutlets: 1, Outlets: []outlets{{Name: "Culvert",
+		From: connection{StorageArea: "Pond"}, To: connection{StorageArea: "Basin"}}}}}
+
+	records := append(structureRecords("Test.g01", hs), structureRecords("Test.g01", storageAreas)...)
+
+	var buf bytes.Buffer
+	if err := WriteStructuresCSV(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+	want := `geom_file,river,reach,river_station,type,name,structure,shape,width,height,length,low_elevation,high_elevation,count
+Test.g01,Creek,Main,450,Inline Weir,Dam,,,20,0,0,100,105.5,1
+Test.g01,Creek,Main,450,Gate,Gate #1,Dam,Sluice,10,5,0,95,100,2
+Test.g01,Creek,Main,420,Pump Station,Lift,,,0,0,0,0,0,3
+Test.g01,Creek,Main,480,Rating Curve Outlet,Spillway,,,0,0,0,101,104.5,0
+Test.g01,,,,Rating Curve Outlet,Culvert,,,0,0,0,0,0,0
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
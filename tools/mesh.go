@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/USACE/mcat-ras/hdf"
+	"github.com/dewberry/gdal"
+)
+
+// MeshStatistics summarizes the computational mesh of a 2D flow area. Cell sizes are the square root of the cell
+// areas, in the model's output units.
+type MeshStatistics struct {
+	GeomFile       string
+	FlowArea       string        `json:"2D Flow Area"`
+	CellCount      int           `json:"Cell Count"`
+	FaceCount      int           `json:"Face Count"`
+	FacePointCount int           `json:"Face Point Count"`
+	MinCellSize    float64       `json:"Min Cell Size"`
+	MeanCellSize   float64       `json:"Mean Cell Size"`
+	MaxCellSize    float64       `json:"Max Cell Size"`
+	Cells          []VectorLayer `json:",omitempty"`
+}
+
+// flowAreaGroup is the group of the geometry HDF files holding a group of datasets per 2D flow area
+const flowAreaGroup string = "/Geometry/2D Flow Areas/"
+
+// meshCell is a cell of a 2D flow area, its row in the cell datasets and the ring of its face points
+type meshCell struct {
+	Index int
+	Ring  [][2]float64
+}
+
+// meshCells returns the cells of a 2D flow area. Rows of the cell face point indexes with fewer than three face
+// points are the virtual cells along the perimeter and are skipped.
+func meshCells(indexes []float64, cols int, rows int, facePoints []float64) []meshCell {
+	cells := []meshCell{}
+	for r := 0; r < rows; r++ {
+		ring := [][2]float64{}
+		for c := 0; c < cols; c++ {
+			idx := int(indexes[r*cols+c])
+			if idx < 0 || 2*idx+1 >= len(facePoints) {
+				continue
+			}
+			ring = append(ring, [2]float64{facePoints[2*idx], facePoints[2*idx+1]})
+		}
+		if len(ring) >= 3 {
+			cells = append(cells, meshCell{r, ring})
+		}
+	}
+	return cells
+}
+
+// summarizeCells records the number of cells and their smallest, mean and largest sizes
+func summarizeCells(stats *MeshStatistics, cells []meshCell, lengthFactor float64) {
+	stats.CellCount = len(cells)
+	sum := 0.0
+	for i, cell := range cells {
+		size := math.Sqrt(polygonArea(cell.Ring)) * lengthFactor
+		if i == 0 || size < stats.MinCellSize {
+			stats.MinCellSize = size
+		}
+		if size > stats.MaxCellSize {
+			stats.MaxCellSize = size
+		}
+		sum += size
+	}
+	if len(cells) > 0 {
+		stats.MeanCellSize = sum / float64(len(cells))
+	}
+}
+
+// cellLayers returns the cells of a 2D flow area as polygons in the destination coordinate reference system, with
+// their cell centers in the model's coordinates
+func cellLayers(flowArea string, cells []meshCell, centers []float64, transform gdal.CoordinateTransform, lengthFactor float64) ([]VectorLayer, error) {
+	layers := []VectorLayer{}
+	for _, cell := range cells {
+		layer := VectorLayer{FeatureName: fmt.Sprintf("%s %d", flowArea, cell.Index), Fields: map[string]interface{}{}}
+		layer.Fields["FlowArea"] = flowArea
+		layer.Fields["CellIndex"] = cell.Index
+		layer.Fields["CellSize"] = math.Sqrt(polygonArea(cell.Ring)) * lengthFactor
+		if 2*cell.Index+1 < len(centers) {
+			layer.Fields["CenterX"] = centers[2*cell.Index]
+			layer.Fields["CenterY"] = centers[2*cell.Index+1]
+		}
+
+		xyLinearRing := gdal.Create(gdal.GT_LinearRing)
+		for _, p := range append(cell.Ring, cell.Ring[0]) {
+			xyLinearRing.AddPoint2D(p[0], p[1])
+		}
+		xyPolygon := gdal.Create(gdal.GT_Polygon)
+		xyPolygon.AddGeometryDirectly(xyLinearRing)
+		xyPolygon.Transform(transform)
+
+		xyMultiPolygon := xyPolygon.ForceToMultiPolygon()
+		wkb, err := xyMultiPolygon.ToWKB()
+		xyMultiPolygon.Destroy()
+		if err != nil {
+			return layers, err
+		}
+		layer.Geometry = wkb
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+// flowAreaMesh reads the mesh of a 2D flow area from a geometry HDF file. Flow areas without face points or cells
+// are reported as not found.
+func flowAreaMesh(h *hdfFile, geomFile string, name string, transform gdal.CoordinateTransform, cells bool, lengthFactor float64) (MeshStatistics, error) {
+	stats := MeshStatistics{GeomFile: geomFile, FlowArea: name}
+	group := flowAreaGroup + name + "/"
+
+	facePoints, shape, err := h.ReadFloats(group + "FacePoints Coordinate")
+	if err != nil {
+		return stats, err
+	}
+	if len(shape) != 2 {
+		return stats, fmt.Errorf("the face point coordinates of %s are not a table", name)
+	}
+	stats.FacePointCount = shape[0]
+
+	_, shape, err = h.ReadFloats(group + "Faces FacePoint Indexes")
+	switch {
+	case err == nil && len(shape) > 0:
+		stats.FaceCount = shape[0]
+	case err != nil && !errors.Is(err, hdf.ErrNotFound):
+		return stats, err
+	}
+
+	indexes, shape, err := h.ReadFloats(group + "Cells FacePoint Indexes")
+	if err != nil {
+		return stats, err
+	}
+	if len(shape) != 2 {
+		return stats, fmt.Errorf("the cell face point indexes of %s are not a table", name)
+	}
+	areaCells := meshCells(indexes, shape[1], shape[0], facePoints)
+	summarizeCells(&stats, areaCells, lengthFactor)
+
+	if cells {
+		centers, _, err := h.ReadFloats(group + "Cells Center Coordinate")
+		if errors.Is(err, hdf.ErrNotFound) {
+			centers, err = []float64{}, nil
+		}
+		if err != nil {
+			return stats, err
+		}
+		stats.Cells, err = cellLayers(name, areaCells, centers, transform, lengthFactor)
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, nil
+}
+
+// geomMesh reads the mesh of each 2D flow area of a geometry HDF file, named as in the flow area attributes
+func (rm *RasModel) geomMesh(hdfFile string, geomFile string, transform gdal.CoordinateTransform, cells bool, lengthFactor float64) ([]MeshStatistics, error) {
+	statistics := []MeshStatistics{}
+
+	h, err := openHDF(rm.FileStore, hdfFile)
+	if err != nil {
+		return statistics, err
+	}
+	defer h.Close()
+
+	names, err := h.ReadStrings(flowAreaGroup+"Attributes", "Name")
+	if errors.Is(err, hdf.ErrNotFound) {
+		return statistics, nil
+	}
+	if err != nil {
+		return statistics, err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		stats, err := flowAreaMesh(h, geomFile, name, transform, cells, lengthFactor)
+		if errors.Is(err, hdf.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return statistics, err
+		}
+		statistics = append(statistics, stats)
+	}
+	return statistics, nil
+}
+
+// Mesh reads the cells, faces and face points of each 2D flow area from the geometry HDF files and returns mesh
+// statistics per flow area. When cells is set, the cell polygons are returned in the destination coordinate
+// reference system.
+func (rm *RasModel) Mesh(destinationCRS int, cells bool) ([]MeshStatistics, error) {
+	statistics := []MeshStatistics{}
+
+	lengthFactor, err := rm.lengthFactor()
+	if err != nil {
+		return statistics, err
+	}
+
+	var transform gdal.CoordinateTransform
+	if cells {
+		if rm.Metadata.Projection == "" {
+			return statistics, errors.New("no valid coordinate reference system")
+		}
+		transform, err = getTransform(rm.Metadata.Projection, destinationCRS)
+		if err != nil {
+			return statistics, err
+		}
+		defer transform.Destroy()
+	}
+
+	for _, g := range rm.Metadata.GeomFiles {
+		hdfFile := ""
+		for _, fp := range rm.FileList {
+			if filepath.Base(fp) == filepath.Base(g.Path)+".hdf" {
+				hdfFile = fp
+				break
+			}
+		}
+		if hdfFile == "" {
+			continue
+		}
+
+		geomStatistics, err := rm.geomMesh(hdfFile, filepath.Base(g.Path), transform, cells, lengthFactor)
+		if err != nil {
+			return statistics, err
+		}
+		statistics = append(statistics, geomStatistics...)
+	}
+	return statistics, nil
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMeshCells(t *testing.T) {
+	// a 2 by 1 mesh of 10 by 10 cells, with a virtual perimeter cell of two face points
+	facePoints := []float64{0, 0, 10, 0, 20, 0, 20, 10, 10, 10, 0, 10}
+	indexes := []float64{
+		0, 1, 4, 5,
+		1, 2, 3, 4,
+		0, 1, -1, -1,
+	}
+
+	got := meshCells(indexes, 4, 3, facePoints)
+	want := []meshCell{
+		{0, [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{1, [][2]float64{{10, 0}, {20, 0}, {20, 10}, {10, 10}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSummarizeCells(t *testing.T) {
+	cells := []meshCell{
+		{0, [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}},
+		{1, [][2]float64{{0, 0}, {20, 0}, {20, 20}, {0, 20}}},
+		{2, [][2]float64{{0, 0}, {30, 0}, {30, 30}, {0, 30}}},
+	}
+
+	tests := []struct {
+		name         string
+		cells        []meshCell
+		lengthFactor float64
+		want         MeshStatistics
+	}{
+		{"model units", cells, 1, MeshStatistics{CellCount: 3, MinCellSize: 10, MeanCellSize: 20, MaxCellSize: 30}},
+		{"converted units", cells, 0.5, MeshStatistics{CellCount: 3, MinCellSize: 5, MeanCellSize: 10, MaxCellSize: 15}},
+		{"no cells", []meshCell{}, 1, MeshStatistics{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			stats := MeshStatistics{}
+			summarizeCells(&stats, tc.cells, tc.lengthFactor)
+			if !reflect.DeepEqual(stats, tc.want) {
+				t.Errorf("got %+v, want %+v", stats, tc.want)
+			}
+		})
+	}
+}
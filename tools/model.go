@@ -7,7 +7,6 @@ import (
 	"log"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 
@@ -27,6 +26,7 @@ type fileExtMatchers struct {
 	UnsteadyRun *regexp.Regexp
 	AllFlowRun  *regexp.Regexp
 	Projection  *regexp.Regexp
+	RasMap      *regexp.Regexp
 }
 
 var rasRE fileExtMatchers = fileExtMatchers{ // Maybe these ones are better? need a regex experts opinion
@@ -41,6 +41,7 @@ var rasRE fileExtMatchers = fileExtMatchers{ // Maybe these ones are better? nee
 	UnsteadyRun: regexp.MustCompile(".x[0-9][0-9]"),     // `^\.x(0[1-9]|[1-9][0-9])$`
 	AllFlowRun:  regexp.MustCompile(".[rx][0-9][0-9]"),  // `^\.[rx](0[1-9]|[1-9][0-9])$`
 	Projection:  regexp.MustCompile(".pr[oj]"),
+	RasMap:      regexp.MustCompile(".rasmap"),
 }
 
 // holder of multiple wait groups to help process files concurrency
@@ -49,12 +50,14 @@ type rasWaitGroup struct {
 	Plan       sync.WaitGroup
 	Flow       sync.WaitGroup
 	Projection sync.WaitGroup
+	RasMap     sync.WaitGroup
 }
 
 // Model is a general type should contain all necessary data for a model of any type.
 type Model struct {
 	Type           string
 	Version        string
+	VersionReport  VersionReport `json:"Version Report"`
 	DefinitionFile string
 	Files          ModelFiles
 }
@@ -92,6 +95,13 @@ type GeometryFiles struct {
 	Paths              []string
 	FeaturesProperties map[string]interface{} // placeholder
 	Georeference       interface{}            // placeholder
+	Units              string
+}
+
+// Georeference is the coordinate reference system of the model and the file it was read from
+type Georeference struct {
+	Projection string
+	Source     string
 }
 
 // OutputFiles is a general type that should contain all data pulled from the models output files
@@ -117,6 +127,8 @@ type RasModel struct {
 	ModelDirectory string
 	FileList       []string
 	Metadata       ProjectMetadata
+	VersionReport  VersionReport
+	OutputUnits    string
 }
 
 // IsAModel ...
@@ -138,11 +150,17 @@ func (rm *RasModel) ModelVersion() string {
 	return rm.Version
 }
 
+// ModelVersionReport ...
+func (rm *RasModel) ModelVersionReport() VersionReport {
+	return rm.VersionReport
+}
+
 // Index ...
 func (rm *RasModel) Index() Model {
 	mod := Model{
 		Type:           rm.Type,
 		Version:        rm.Version,
+		VersionReport:  rm.VersionReport,
 		DefinitionFile: filepath.Base(rm.Metadata.ProjFilePath),
 		Files: ModelFiles{
 			InputFiles: InputFiles{
@@ -157,7 +175,8 @@ func (rm *RasModel) Index() Model {
 				GeometryFiles: GeometryFiles{
 					Paths:              make([]string, 0),
 					FeaturesProperties: make(map[string]interface{}),
-					Georeference:       rm.Metadata.Projection,
+					Georeference:       Georeference{rm.Metadata.Projection, rm.Metadata.ProjectionSource},
+					Units:              rm.units(),
 				},
 				SimulationVariables: nil,
 				LocalVariables:      nil,
@@ -169,8 +188,8 @@ func (rm *RasModel) Index() Model {
 				RunLogs:         make([]string, 0),
 			},
 			SupplementalFiles: SupplementalFiles{
-				Paths:             make([]string, 0),
-				Visulizations:     nil,
+				Paths:             rm.Metadata.RasMapFile.supplementalPaths(),
+				Visulizations:     rm.Metadata.RasMapFile,
 				ObservationalData: nil,
 			},
 		},
@@ -192,7 +211,7 @@ func (rm *RasModel) Index() Model {
 		mod.Files.InputFiles.ForcingFiles.Data[file] = f
 	}
 
-	// Need to add output and SupplementalFiles files...
+	// Need to add output files...
 	return mod
 }
 
@@ -202,42 +221,48 @@ func (rm *RasModel) IsGeospatial() bool {
 		log.Println("no valid coordinate reference system")
 		return false
 	}
-	modelVersions := strings.Split(rm.Version, ",")
-	for _, version := range modelVersions {
-		if strings.Contains(version, ".g") {
-			geomVersion := strings.TrimSpace(strings.Split(version, ":")[1])
-			v, err := strconv.ParseFloat(geomVersion, 64)
-			if err != nil {
-				log.Println("could not convert the geometry version to a float")
-				return false
-			}
-			if v < 4 {
-				log.Printf("geometry file version: %f is not geospatial", v)
-				return false
-			}
+	for _, g := range rm.Metadata.GeomFiles {
+		gr := g.Georeference
+		if !gr.Ready {
+			log.Printf("geometry file %s is not geospatial: %v%% of reaches have coordinates, %v%% of cross-sections have cut lines, %v%% of storage areas have surface lines",
+				filepath.Base(g.Path), gr.PctReachesWithXY, gr.PctCrossSectionsWithCutLines, gr.PctStorageAreasWithSurface)
+			return false
+		}
+		if gr.ReachesWithXY < gr.Reaches || gr.CrossSectionsWithCutLines < gr.CrossSections || gr.StorageAreasWithSurfaceLines < gr.StorageAreas {
+			// cross-sections without cut lines are skipped when the geospatial data is extracted
+			log.Printf("geometry file %s is partially geospatial: %v%% of reaches have coordinates, %v%% of cross-sections have cut lines, %v%% of storage areas have surface lines",
+				filepath.Base(g.Path), gr.PctReachesWithXY, gr.PctCrossSectionsWithCutLines, gr.PctStorageAreasWithSurface)
 		}
 	}
 
 	return true
 }
 
-// GeospatialData ...
-func (rm *RasModel) GeospatialData(destinationCRS int) (GeoData, error) {
-	gd := GeoData{}
+// GeospatialData extracts the model's features. When scaleStations is set, cross-sections whose cut line and
+// profile lengths differ have their stations scaled along the cut line so that banks and 3D lines are produced.
+// When warnUnits is set, a unit system that does not match the coordinate reference system is reported as a
+// warning instead of stopping the extraction. US survey and international feet are always only a warning.
+func (rm *RasModel) GeospatialData(destinationCRS int, scaleStations bool, warnUnits bool) (GeoData, error) {
+	gd := GeoData{Warnings: make([]string, 0)}
 	if rm.IsGeospatial() {
 		modelUnits := rm.Metadata.ProjFileContents.Units
 
 		sourceCRS := rm.Metadata.Projection
 
 		if err := checkUnitConsistency(modelUnits, sourceCRS); err != nil {
-			return gd, err
+			if !warnUnits && err != errInternationalFoot {
+				return gd, err
+			}
+			log.Println(err)
+			gd.Warnings = append(gd.Warnings, err.Error())
 		}
 
 		gd.Features = make(map[string]Features)
 		gd.Georeference = destinationCRS
+		gd.Units = rm.units()
 
 		for _, g := range rm.Metadata.GeomFiles {
-			if err := GetGeospatialData(&gd, rm.FileStore, g.Path, sourceCRS, destinationCRS); err != nil {
+			if err := GetGeospatialData(&gd, rm.FileStore, g.Path, sourceCRS, destinationCRS, scaleStations, modelUnits, rm.OutputUnits); err != nil {
 				return gd, err
 			}
 		}
@@ -264,7 +289,7 @@ func getModelFiles(rm *RasModel) error {
 }
 
 // getProjection Reads a projection file. returns none to allow concurrency
-func getProjection(rm *RasModel, fn string, wg *sync.WaitGroup) {
+func getProjection(rm *RasModel, fn string, source string, wg *sync.WaitGroup) {
 
 	defer wg.Done()
 
@@ -279,7 +304,28 @@ func getProjection(rm *RasModel, fn string, wg *sync.WaitGroup) {
 	sc.Scan()
 	line := sc.Text()
 
-	sourceSpRef := gdal.CreateSpatialReference(line)
+	setProjection(rm, line, fn, source)
+
+	return
+}
+
+// getHDFProjection reads the projection attribute of a geometry HDF file
+func getHDFProjection(rm *RasModel, fn string) {
+	wkt, err := getHDFAttribute(rm.FileStore, fn, "Projection")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	if wkt == "" {
+		return
+	}
+
+	setProjection(rm, wkt, fn, "Geometry HDF")
+}
+
+// setProjection validates a coordinate reference system and records it along with where it came from
+func setProjection(rm *RasModel, wkt string, fn string, source string) {
+	sourceSpRef := gdal.CreateSpatialReference(wkt)
 	if err := sourceSpRef.Validate(); err != nil {
 		fmt.Println(fmt.Sprintf("%s is not a valid projection file.\n", fn))
 		fmt.Println(err)
@@ -290,9 +336,40 @@ func getProjection(rm *RasModel, fn string, wg *sync.WaitGroup) {
 		return
 	}
 
-	rm.Metadata.Projection = line
+	rm.Metadata.Projection = wkt
+	rm.Metadata.ProjectionSource = fmt.Sprintf("%s (%s)", source, filepath.Base(fn))
+}
+
+// getFallbackProjection looks for a coordinate reference system in the RAS Mapper file
+// and the geometry HDF files when no projection file was found in the model directory
+func getFallbackProjection(rm *RasModel) {
+	var wg sync.WaitGroup
+
+	rasMapProjection := rm.Metadata.RasMapFile.ProjectionFile
+	if rasMapProjection.Filename != "" {
+		fn := rasMapProjection.Filename
+		if !rasMapProjection.Exists {
+			// RAS Mapper often references a projection outside of the model delivery by its absolute path
+			fn = filepath.Join(rm.ModelDirectory, filepath.Base(fn))
+		}
+		if fileExists(rm.FileStore, fn) {
+			wg.Add(1)
+			getProjection(rm, fn, "RAS Mapper", &wg)
+		}
+	}
 
-	return
+	for _, g := range rm.Metadata.GeomFiles {
+		if rm.Metadata.Projection != "" {
+			return
+		}
+		hdfFile := g.Path + ".hdf"
+		for _, fp := range rm.FileList {
+			if filepath.Base(fp) == filepath.Base(hdfFile) {
+				getHDFProjection(rm, fp)
+				break
+			}
+		}
+	}
 }
 
 // NewRasModel ...
@@ -319,7 +396,9 @@ func NewRasModel(key string, fs filestore.FileStore) (*RasModel, error) {
 	// get projection using name.projection file
 	rasWG.Projection.Add(1)
 	projecFile := strings.TrimSuffix(key, ".prj") + ".projection"
-	go getProjection(&rm, projecFile, &rasWG.Projection)
+	go getProjection(&rm, projecFile, "Projection File", &rasWG.Projection)
+
+	rasMapFile := strings.TrimSuffix(key, ".prj") + ".rasmap"
 
 	for _, fp := range rm.FileList {
 
@@ -339,10 +418,16 @@ func NewRasModel(key string, fs filestore.FileStore) (*RasModel, error) {
 			rasWG.Flow.Add(1)
 			go getFlowData(&rm, fp, &rasWG.Flow)
 
+		case rasRE.RasMap.MatchString(ext):
+			if filepath.Base(fp) == filepath.Base(rasMapFile) {
+				rasWG.RasMap.Add(1)
+				go getRasMapData(&rm, fp, &rasWG.RasMap)
+			}
+
 		case rm.Metadata.Projection == "" && rasRE.Projection.MatchString(ext):
 			if filepath.Base(key) != filepath.Base(fp) && fp != projecFile {
 				rasWG.Projection.Add(1)
-				go getProjection(&rm, fp, &rasWG.Projection)
+				go getProjection(&rm, fp, "Projection File", &rasWG.Projection)
 			}
 
 		}
@@ -352,29 +437,16 @@ func NewRasModel(key string, fs filestore.FileStore) (*RasModel, error) {
 	rasWG.Geom.Wait()
 	rasWG.Flow.Wait()
 	rasWG.Projection.Wait()
+	rasWG.RasMap.Wait()
 
-	for _, p := range rm.Metadata.PlanFiles {
-		version := p.ProgramVersion
-		if version != "" {
-			rm.Version += fmt.Sprintf("%s: %s, ", p.FileExt, version)
-		}
-	}
-	for _, g := range rm.Metadata.GeomFiles {
-		version := g.ProgramVersion
-		if version != "" {
-			rm.Version += fmt.Sprintf("%s: %s, ", g.FileExt, version)
-		}
-	}
-	for _, f := range rm.Metadata.FlowFiles {
-		version := f.ProgramVersion
-		if version != "" {
-			rm.Version += fmt.Sprintf("%s: %s, ", f.FileExt, version)
-		}
+	if rm.Metadata.Projection == "" {
+		getFallbackProjection(&rm)
 	}
 
-	if len(rm.Version) >= 2 {
-		rm.Version = rm.Version[0 : len(rm.Version)-2]
-	}
+	linkGateOpenings(&rm)
+
+	rm.VersionReport = getVersionReport(&rm)
+	rm.Version = fileVersions(&rm)
 
 	return &rm, nil
 }
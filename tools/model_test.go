@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetFallbackProjection(t *testing.T) {
+	rasMapProjection := func(dir string) MapLayer { return MapLayer{Filename: filepath.Join(dir, "Model.prj"), Exists: true} }
+	outsideProjection := func(dir string) MapLayer { return MapLayer{Filename: "/GIS/Projections/Model.prj"} }
+	missingProjection := func(dir string) MapLayer { return MapLayer{Filename: "/GIS/Projections/Other.prj"} }
+	noProjection := func(dir string) MapLayer { return MapLayer{} }
+
+	tests := []struct {
+		name          string
+		projection    func(dir string) MapLayer
+		hdfProjection bool
+		wantSource    string
+	}{
+		{"RAS Mapper projection in the model directory", rasMapProjection, false, "RAS Mapper (Model.prj)"},
+		{"RAS Mapper projection referenced outside the delivery", outsideProjection, false, "RAS Mapper (Model.prj)"},
+		{"RAS Mapper projection before the geometry HDF", rasMapProjection, true, "RAS Mapper (Model.prj)"},
+		{"RAS Mapper projection missing, geometry HDF projection", missingProjection, true, "Geometry HDF (Test.g01.hdf)"},
+		{"no RAS Mapper projection, geometry HDF projection", noProjection, true, "Geometry HDF (Test.g01.hdf)"},
+		{"no RAS Mapper or geometry HDF projection", noProjection, false, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// a VRT stands in for the geometry HDF, gdal reads its root metadata the same way
+			hdf := ""
+			if tc.hdfProjection {
+				hdf = fmt.Sprintf(`<VRTDataset rasterXSize="1" rasterYSize="1"><Metadata><MDI key="Projection">%s</MDI></Metadata></VRTDataset>`,
+					epsgWKT(t, 26915))
+			}
+			rm, dir := testModel(t, map[string]string{"Model.prj": epsgWKT(t, 2278), "Test.g01": "Geom Title=Test\n", "Test.g01.hdf": hdf})
+			rm.FileList = []string{filepath.Join(dir, "Model.prj"), filepath.Join(dir, "Test.g01"), filepath.Join(dir, "Test.g01.hdf")}
+			rm.Metadata.GeomFiles = []GeomFileContents{{Path: filepath.Join(dir, "Test.g01"), FileExt: ".g01"}}
+			rm.Metadata.RasMapFile.ProjectionFile = tc.projection(dir)
+
+			getFallbackProjection(rm)
+			if rm.Metadata.ProjectionSource != tc.wantSource {
+				t.Errorf("got projection from %q, want %q", rm.Metadata.ProjectionSource, tc.wantSource)
+			}
+			if (rm.Metadata.Projection != "") != (tc.wantSource != "") {
+				t.Errorf("got projection %q", rm.Metadata.Projection)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"strconv"
+)
+
+// XSProfile is the station-elevation profile of a cross-section and the attributes defined along it
+type XSProfile struct {
+	GeomFile         string
+	River            string
+	Reach            string
+	RiverStation     string            `json:"River Station"`
+	Stations         []float64         `json:"Stations"`
+	Elevations       []float64         `json:"Elevations"`
+	ManningsN        []ManningsBreak   `json:"Mannings N"`
+	BankStations     []float64         `json:"Bank Stations"`
+	IneffectiveAreas []IneffectiveArea `json:"Ineffective Areas"`
+	Levees           []Levee           `json:"Levees"`
+}
+
+// ManningsBreak is the station at which a Manning's n value begins
+type ManningsBreak struct {
+	Station float64
+	N       float64
+}
+
+// IneffectiveArea is a portion of a cross-section that does not convey flow below its elevation
+type IneffectiveArea struct {
+	StartStation float64 `json:"Start Station"`
+	EndStation   float64 `json:"End Station"`
+	Elevation    float64
+	Permanent    bool
+}
+
+// Levee is a levee point on the left or right side of a cross-section
+type Levee struct {
+	Side      string
+	Station   float64
+	Elevation float64
+}
+
+func newXSProfile(geomFile string, reach reachGeometry, xs xsGeometry) XSProfile {
+	profile := XSProfile{
+		GeomFile:         geomFile,
+		River:            reach.River,
+		Reach:            reach.Reach,
+		RiverStation:     xs.Name,
+		Stations:         make([]float64, 0),
+		Elevations:       make([]float64, 0),
+		ManningsN:        make([]ManningsBreak, 0),
+		BankStations:     make([]float64, 0),
+		IneffectiveAreas: make([]IneffectiveArea, 0),
+		Levees:           make([]Levee, 0),
+	}
+
+	for _, pair := range xs.Profile {
+		profile.Stations = append(profile.Stations, pair[0])
+		profile.Elevations = append(profile.Elevations, pair[1])
+	}
+	for _, triplet := range xs.Mannings {
+		profile.ManningsN = append(profile.ManningsN, ManningsBreak{Station: triplet[0], N: triplet[1]})
+	}
+	profile.BankStations = append(profile.BankStations, xs.BankStations...)
+	for i, triplet := range xs.Ineffective {
+		area := IneffectiveArea{StartStation: triplet[0], EndStation: triplet[1], Elevation: triplet[2]}
+		if i < len(xs.Permanent) {
+			area.Permanent = xs.Permanent[i]
+		}
+		profile.IneffectiveAreas = append(profile.IneffectiveAreas, area)
+	}
+	for i, side := range []string{"Left", "Right"} {
+		if len(xs.Levees[i]) == 2 {
+			profile.Levees = append(profile.Levees, Levee{Side: side, Station: xs.Levees[i][0], Elevation: xs.Levees[i][1]})
+		}
+	}
+	return profile
+}
+
+// XSProfiles returns the station-elevation profile and attributes of every cross-section in the model, in the
+// model's output units
+func (rm *RasModel) XSProfiles() ([]XSProfile, error) {
+	profiles := []XSProfile{}
+	f, err := rm.lengthFactor()
+	if err != nil {
+		return profiles, err
+	}
+	for _, g := range rm.Metadata.GeomFiles {
+		reaches, err := readXSGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return profiles, err
+		}
+		for _, reach := range reaches {
+			for _, xs := range reach.XS {
+				profile := newXSProfile(filepath.Base(g.Path), reach, xs)
+				profile.scale(f)
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// manningsNAt returns the Manning's n value in effect at a station
+func (p XSProfile) manningsNAt(station float64) float64 {
+	n := 0.0
+	for _, b := range p.ManningsN {
+		if b.Station <= station {
+			n = b.N
+		}
+	}
+	return n
+}
+
+// ineffectiveAt returns the elevation of the ineffective area containing a station, if there is one
+func (p XSProfile) ineffectiveAt(station float64) (float64, bool) {
+	for _, area := range p.IneffectiveAreas {
+		if station >= area.StartStation && station <= area.EndStation {
+			return area.Elevation, true
+		}
+	}
+	return 0, false
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// WriteXSProfilesCSV writes one row per profile point, repeating the cross-section's bank stations and
+// levees on each row and giving the Manning's n and ineffective area in effect at the point
+func WriteXSProfilesCSV(w io.Writer, profiles []XSProfile) error {
+	cw := csv.NewWriter(w)
+	header := []string{"geom_file", "river", "reach", "river_station", "station", "elevation", "mannings_n",
+		"left_bank_station", "right_bank_station", "ineffective", "ineffective_elevation",
+		"left_levee_station", "left_levee_elevation", "right_levee_station", "right_levee_elevation"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, p := range profiles {
+		banks := []string{"", ""}
+		for i, b := range p.BankStations {
+			if i < 2 {
+				banks[i] = formatFloat(b)
+			}
+		}
+		levees := []string{"", "", "", ""}
+		for _, l := range p.Levees {
+			i := 0
+			if l.Side == "Right" {
+				i = 2
+			}
+			levees[i], levees[i+1] = formatFloat(l.Station), formatFloat(l.Elevation)
+		}
+
+		for i, station := range p.Stations {
+			ineffective, ineffectiveElev := "false", ""
+			if elev, ok := p.ineffectiveAt(station); ok {
+				ineffective, ineffectiveElev = "true", formatFloat(elev)
+			}
+			row := []string{p.GeomFile, p.River, p.Reach, p.RiverStation, formatFloat(station), formatFloat(p.Elevations[i]),
+				formatFloat(p.manningsNAt(station)), banks[0], banks[1], ineffective, ineffectiveElev}
+			row = append(row, levees...)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteXSProfilesCSV(t *testing.T) {
+	reach := reachGeometry{River: "Creek", Reach: "Main"}
+	xs := xsGeometry{
+		Name:         "1500",
+		Profile:      [][2]float64{{0, 110}, {50, 104}, {100, 100}, {150, 104}, {200, 112}},
+		Mannings:     [][3]float64{{0, 0.06, 0}, {50, 0.035, 0}, {150, 0.06, 0}},
+		BankStations: []float64{50, 150},
+		Ineffective:  [][3]float64{{160, 200, 108}},
+		Permanent:    []bool{true},
+		Levees:       [2][]float64{{}, {200, 112}},
+	}
+	profile := newXSProfile("Test.g01", reach, xs)
+
+	var buf bytes.Buffer
+	if err := WriteXSProfilesCSV(&buf, []XSProfile{profile}); err != nil {
+		t.Fatal(err)
+	}
+	want := `geom_file,river,reach,river_station,station,elevation,mannings_n,left_bank_station,right_bank_station,ineffective,ineffective_elevation,left_levee_station,left_levee_elevation,right_levee_station,right_levee_elevation
+Test.g01,Creek,Main,1500,0,110,0.06,50,150,false,,,,200,112
+Test.g01,Creek,Main,1500,50,104,0.035,50,150,false,,,,200,112
+Test.g01,Creek,Main,1500,100,100,0.035,50,150,false,,,,200,112
+Test.g01,Creek,Main,1500,150,104,0.06,50,150,false,,,,200,112
+Test.g01,Creek,Main,1500,200,112,0.06,50,150,true,108,,,200,112
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestNewXSProfile(t *testing.T) {
+	xs := xsGeometry{Name: "1500", Ineffective: [][3]float64{{0, 20, 105}, {180, 200, 106}}, Permanent: []bool{false, true},
+		Levees: [2][]float64{{10, 111}, {}}}
+	p := newXSProfile("Test.g01", reachGeometry{River: "Creek", Reach: "Main"}, xs)
+
+	if len(p.IneffectiveAreas) != 2 || p.IneffectiveAreas[0].Permanent || !p.IneffectiveAreas[1].Permanent {
+		t.Errorf("got ineffective areas %+v", p.IneffectiveAreas)
+	}
+	if len(p.Levees) != 1 || p.Levees[0] != (Levee{"Left", 10, 111}) {
+		t.Errorf("got levees %+v", p.Levees)
+	}
+}
@@ -17,7 +17,9 @@ type ProjectMetadata struct {
 	PlanFiles        []PlanFileContents //`json:"Plan Data"`
 	FlowFiles        []FlowFileContents //`json:"Flow Data"`
 	GeomFiles        []GeomFileContents //`json:"Geometry Data"`
+	RasMapFile       RasMapContents     //`json:"RAS Mapper Data"`
 	Projection       string             //`json:"Projection"`
+	ProjectionSource string             //`json:"Projection Source"`
 	Notes            string             //`json:"Notes"`
 }
 
@@ -29,7 +31,7 @@ type PrjFileContents struct {
 	QuasiSteadyFile []string //`json:"QuasiSteady File"`
 	UnsteadyFile    []string //`json:"Unsteady File"`
 	GeomFile        []string //`json:"Geom File"`
-	Units           string   //`json:"Units"`
+	Units           string   //`json:"Units"` English or SI
 	CurrentPlan     string   //`json:"Current Plan"`
 	Description     string   //`json:"Description"`
 } //
@@ -148,7 +150,7 @@ func getPrjData(rm *RasModel) error {
 			}
 
 		} else if units {
-			meta.Units = line
+			meta.Units = parseUnitSystem(line)
 		}
 	}
 
@@ -0,0 +1,279 @@
+package tools
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"github.com/dewberry/gdal"
+)
+
+type pumpStationData struct {
+	NumPumpStations int            `json:"Num Pump Stations"`
+	PumpStations    []pumpStations `json:"Pump Stations"`
+}
+
+type pumpStations struct {
+	Name      string
+	X         float64 `json:"-"`
+	Y         float64 `json:"-"`
+	From      connection
+	To        connection
+	NumGroups int          `json:"Num Pump Groups"`
+	Groups    []pumpGroups `json:"Pump Groups"`
+}
+
+type pumpGroups struct {
+	Name     string
+	NumPumps int     `json:"Num Pumps"`
+	Pumps    []pumps `json:"Pumps"`
+	// Curve is the pump curve as head and flow pairs
+	Curve [][2]float64 `json:"Pump Curve"`
+}
+
+// pumps are the elevations at which a pump turns on and off
+type pumps struct {
+	OnElevation  float64 `json:"On Elevation"`
+	OffElevation float64 `json:"Off Elevation"`
+}
+
+type outletData struct {
+	NumOutlets int       `json:"Num Rating Curve Outlets"`
+	Outlets    []outlets `json:"Rating Curve Outlets"`
+}
+
+// outlets are storage area connections whose flow is given by a rating curve
+type outlets struct {
+	Name string
+	From connection
+	To   connection
+	// RatingCurve is the outlet rating curve as elevation and flow pairs
+	RatingCurve [][2]float64 `json:"Rating Curve"`
+}
+
+// connection is the river station or storage area a structure draws from or discharges to
+type connection struct {
+	River        string `json:",omitempty"`
+	Reach        string `json:",omitempty"`
+	RiverStation string `json:"River Station,omitempty"`
+	StorageArea  string `json:"Storage Area,omitempty"`
+}
+
+// getConnection reads a river, reach and river station, or a storage area name alone
+func getConnection(s string) connection {
+	data := strings.Split(s, ",")
+	for i := range data {
+		data[i] = strings.TrimSpace(data[i])
+	}
+	if len(data) >= 3 && data[1] != "" {
+		return connection{River: data[0], Reach: data[1], RiverStation: data[2]}
+	}
+	return connection{StorageArea: data[0]}
+}
+
+// endsGeomBlock is true for the lines that begin a new river reach, storage area or storage area structure
+func endsGeomBlock(line string) bool {
+	for _, prefix := range []string{"River Reach=", "Storage Area=", "Pump Station=", "Connection=", "Type RM Length L Ch R ="} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCurve reads the number of pairs given on a line and the pairs that follow it
+func getCurve(line string, sc *bufio.Scanner) ([][2]float64, error) {
+	nPairs, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+	if err != nil || nPairs == 0 {
+		return [][2]float64{}, err
+	}
+	return dataPairsfromTextBlock(sc, nPairs, 80, 8)
+}
+
+// getPumpStationData reads the "Pump Station=" block starting on line i. The block gives the station's name and
+// location, the river station or storage area it pumps from and to, and for each "Pump Group=" its name and number
+// of pumps followed by a "Pump On Off=" line per pump and a "Pump Curve=" of head and flow pairs.
+func getPumpStationData(rm *RasModel, fn string, i int) (pumpStations, error) {
+	pump := pumpStations{Groups: []pumpGroups{}}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return pump, err
+	}
+	defer f.Close()
+
+	pSc := bufio.NewScanner(f)
+
+	pi := 0
+	for pSc.Scan() {
+		pi++
+		line := pSc.Text()
+		if pi == i {
+			lineData := strings.Split(rightofEquals(line), ",")
+			pump.Name = strings.TrimSpace(lineData[0])
+			if len(lineData) > 2 {
+				if pump.X, err = stringtoFloat(lineData[1]); err != nil {
+					return pump, err
+				}
+				if pump.Y, err = stringtoFloat(lineData[2]); err != nil {
+					return pump, err
+				}
+			}
+		} else if pi > i {
+			switch {
+			case strings.HasPrefix(line, "Pump From="):
+				pump.From = getConnection(rightofEquals(line))
+
+			case strings.HasPrefix(line, "Pump To="):
+				pump.To = getConnection(rightofEquals(line))
+
+			case strings.HasPrefix(line, "Pump Group="):
+				lineData := strings.Split(rightofEquals(line), ",")
+				group := pumpGroups{Name: strings.TrimSpace(lineData[0]), Pumps: []pumps{}, Curve: [][2]float64{}}
+				if len(lineData) > 1 {
+					if group.NumPumps, err = strconv.Atoi(strings.TrimSpace(lineData[1])); err != nil {
+						return pump, err
+					}
+				}
+				pump.Groups = append(pump.Groups, group)
+				pump.NumGroups++
+
+			case strings.HasPrefix(line, "Pump On Off=") && pump.NumGroups > 0:
+				lineData := strings.Split(rightofEquals(line), ",")
+				p := pumps{}
+				if p.OnElevation, err = stringtoFloat(lineData[0]); err != nil {
+					return pump, err
+				}
+				if len(lineData) > 1 {
+					if p.OffElevation, err = stringtoFloat(lineData[1]); err != nil {
+						return pump, err
+					}
+				}
+				group := &pump.Groups[pump.NumGroups-1]
+				group.Pumps = append(group.Pumps, p)
+
+			case strings.HasPrefix(line, "Pump Curve=") && pump.NumGroups > 0:
+				curve, err := getCurve(line, pSc)
+				if err != nil {
+					return pump, err
+				}
+				pump.Groups[pump.NumGroups-1].Curve = curve
+
+			case endsGeomBlock(line):
+				return pump, nil
+			}
+		}
+	}
+	return pump, nil
+}
+
+// getOutletData reads the "Connection=" block starting on line i and returns it as an outlet when it has a
+// "Conn Outlet Rating Curve=" of elevation and flow pairs
+func getOutletData(rm *RasModel, fn string, i int) (outlets, bool, error) {
+	outlet := outlets{RatingCurve: [][2]float64{}}
+	hasRatingCurve := false
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return outlet, false, err
+	}
+	defer f.Close()
+
+	oSc := bufio.NewScanner(f)
+
+	oi := 0
+	for oSc.Scan() {
+		oi++
+		line := oSc.Text()
+		if oi == i {
+			outlet.Name = strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0])
+		} else if oi > i {
+			switch {
+			case strings.HasPrefix(line, "Connection Up SA="):
+				outlet.From = connection{StorageArea: strings.TrimSpace(rightofEquals(line))}
+
+			case strings.HasPrefix(line, "Connection Dn SA="):
+				outlet.To = connection{StorageArea: strings.TrimSpace(rightofEquals(line))}
+
+			case strings.HasPrefix(line, "Conn Outlet Rating Curve="):
+				curve, err := getCurve(line, oSc)
+				if err != nil {
+					return outlet, false, err
+				}
+				outlet.RatingCurve = curve
+				hasRatingCurve = len(curve) > 0
+
+			case endsGeomBlock(line):
+				return outlet, hasRatingCurve, nil
+			}
+		}
+	}
+	return outlet, hasRatingCurve, nil
+}
+
+// addStorageAreaStructures adds pump stations and outlets to the structures of the reach they draw from or
+// discharge to. Those connecting only storage areas are added to structures without a river and reach.
+func addStorageAreaStructures(structures []hydraulicStructures, stations []pumpStations, ratingOutlets []outlets) []hydraulicStructures {
+	reachIndex := func(from, to connection) int {
+		for i, hs := range structures {
+			for _, c := range []connection{from, to} {
+				if c.River != "" && c.River == hs.River && c.Reach == hs.Reach {
+					return i
+				}
+			}
+		}
+		for i, hs := range structures {
+			if hs.River == "" && hs.Reach == "" {
+				return i
+			}
+		}
+		structures = append(structures, hydraulicStructures{
+			PumpData:   pumpStationData{PumpStations: []pumpStations{}},
+			OutletData: outletData{Outlets: []outlets{}},
+		})
+		return len(structures) - 1
+	}
+
+	for _, p := range stations {
+		idx := reachIndex(p.From, p.To)
+		hs := &structures[idx]
+		hs.PumpData.PumpStations = append(hs.PumpData.PumpStations, p)
+		hs.PumpData.NumPumpStations++
+	}
+	for _, o := range ratingOutlets {
+		idx := reachIndex(o.From, o.To)
+		hs := &structures[idx]
+		hs.OutletData.Outlets = append(hs.OutletData.Outlets, o)
+		hs.OutletData.NumOutlets++
+	}
+	return structures
+}
+
+// getPumpStation returns a pump station as a point at its location
+func getPumpStation(line string, transform gdal.CoordinateTransform) (VectorLayer, bool, error) {
+	lineData := strings.Split(rightofEquals(line), ",")
+	layer := VectorLayer{FeatureName: strings.TrimSpace(lineData[0]), Fields: map[string]interface{}{}}
+	if len(lineData) < 3 || strings.TrimSpace(lineData[1]) == "" || strings.TrimSpace(lineData[2]) == "" {
+		return layer, false, nil
+	}
+
+	x, err := stringtoFloat(lineData[1])
+	if err != nil {
+		return layer, false, err
+	}
+	y, err := stringtoFloat(lineData[2])
+	if err != nil {
+		return layer, false, err
+	}
+
+	xyPoint := gdal.Create(gdal.GT_Point)
+	xyPoint.AddPoint2D(x, y)
+	xyPoint.Transform(transform)
+	multiPoint := xyPoint.ForceToMultiPoint()
+	wkb, err := multiPoint.ToWKB()
+	if err != nil {
+		return layer, false, err
+	}
+	layer.Geometry = wkb
+	return layer, true, nil
+}
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const pumpGeom = `Storage Area=Pond,100,200
+Pump Station=PS1,150.5,250.25
+Pump From=Pond
+Pump To=Creek,Main,350
+Pump Group=Group A,2
+Pump On Off=101,99
+Pump On Off=102,99.5
+Pump Curve= 2
+       0      50      10      20
+Connection=Outlet1,1,2
+Connection Up SA=Pond
+Connection Dn SA=Pond2
+Conn Outlet Rating Curve= 3
+     100       0     101       5     102      20
+Connection=Plain,1,2
+Connection Up SA=Pond
+`
+
+func TestGetPumpStationData(t *testing.T) {
+	rm, dir := testModel(t, map[string]string{"Test.g01": pumpGeom})
+
+	pump, err := getPumpStationData(rm, filepath.Join(dir, "Test.g01"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pumpStations{Name: "PS1", X: 150.5, Y: 250.25, From: connection{StorageArea: "Pond"},
+		To: connection{River: "Creek", Reach: "Main", RiverStation: "350"}, NumGroups: 1,
+		Groups: []pumpGroups{{Name: "Group A", NumPumps: 2, Pumps: []pumps{{101, 99}, {102, 99.5}},
+			Curve: [][2]float64{{0, 50}, {10, 20}}}}}
+	if !reflect.DeepEqual(pump, want) {
+		t.Errorf("got %+v, want %+v", pump, want)
+	}
+}
+
+func TestGetOutletData(t *testing.T) {
+	rm, dir := testModel(t, map[string]string{"Test.g01": pumpGeom})
+
+	tests := []struct {
+		line      int
+		want      outlets
+		hasOutlet bool
+	}{
+		{10, outlets{Name: "Outlet1", From: connection{StorageArea: "Pond"}, To: connection{StorageArea: "Pond2"},
+			RatingCurve: [][2]float64{{100, 0}, {101, 5}, {102, 20}}}, true},
+		{15, outlets{}, false},
+	}
+	for _, tc := range tests {
+		outlet, ok, err := getOutletData(rm, filepath.Join(dir, "Test.g01"), tc.line)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tc.hasOutlet {
+			t.Errorf("line %d: got rating curve outlet %v, want %v", tc.line, ok, tc.hasOutlet)
+		}
+		if ok && !reflect.DeepEqual(outlet, tc.want) {
+			t.Errorf("line %d: got %+v, want %+v", tc.line, outlet, tc.want)
+		}
+	}
+}
+
+func TestAddStorageAreaStructures(t *testing.T) {
+	structures := []hydraulicStructures{{River: "Creek", Reach: "Main"}}
+	stations := []pumpStations{
+		{Name: "PS1", From: connection{StorageArea: "Pond"}, To: connection{River: "Creek", Reach: "Main", RiverStation: "350"}},
+		{Name: "PS2", From: connection{StorageArea: "Pond"}, To: connection{StorageArea: "Pond2"}},
+		{Name: "PS3", From: connection{StorageArea: "Pond2"}, To: connection{StorageArea: "Pond"}},
+	}
+	ratingOutlets := []outlets{{Name: "Outlet1", From: connection{StorageArea: "Pond"}, To: connection{StorageArea: "Pond2"}}}
+
+	structures = addStorageAreaStructures(structures, stations, ratingOutlets)
+	if len(structures) != 2 {
+		t.Fatalf("got %d reaches, want 2", len(structures))
+	}
+	if n := structures[0].PumpData.NumPumpStations; n != 1 {
+		t.Errorf("got %d pump stations on the reach, want 1", n)
+	}
+	if n, m := structures[1].PumpData.NumPumpStations, structures[1].OutletData.NumOutlets; n != 2 || m != 1 {
+		t.Errorf("got %d pump stations and %d outlets between storage areas, want 2 and 1", n, m)
+	}
+}
+
+func TestScalePumpsAndOutlets(t *testing.T) {
+	hs := hydraulicStructures{
+		PumpData: pumpStationData{PumpStations: []pumpStations{{Groups: []pumpGroups{{Pumps: []pumps{{101, 99}},
+			Curve: [][2]float64{{10, 20}}}}}}},
+		OutletData: outletData{Outlets: []outlets{{RatingCurve: [][2]float64{{100, 5}}}}},
+	}
+	hs.scale(2)
+
+	g := hs.PumpData.PumpStations[0].Groups[0]
+	if g.Pumps[0] != (pumps{202, 198}) || g.Curve[0] != [2]float64{20, 160} {
+		t.Errorf("got pumps %+v and curve %v", g.Pumps, g.Curve)
+	}
+	if c := hs.OutletData.Outlets[0].RatingCurve[0]; c != [2]float64{200, 40} {
+		t.Errorf("got rating curve %v", c)
+	}
+}
@@ -0,0 +1,255 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dewberry/gdal"
+)
+
+// QAFinding is a problem found in a model's geometry. The location is given in the destination coordinate reference system.
+type QAFinding struct {
+	GeomFile string
+	Check    string
+	Feature  string
+	Message  string
+	Location [2]float64
+}
+
+// bbox returns the minimum and maximum x and y of a line
+func bbox(xyPairs [][2]float64) [4]float64 {
+	b := [4]float64{xyPairs[0][0], xyPairs[0][1], xyPairs[0][0], xyPairs[0][1]}
+	for _, p := range xyPairs {
+		if p[0] < b[0] {
+			b[0] = p[0]
+		}
+		if p[1] < b[1] {
+			b[1] = p[1]
+		}
+		if p[0] > b[2] {
+			b[2] = p[0]
+		}
+		if p[1] > b[3] {
+			b[3] = p[1]
+		}
+	}
+	return b
+}
+
+func bboxOverlap(a, b [4]float64) bool {
+	return a[0] <= b[2] && b[0] <= a[2] && a[1] <= b[3] && b[1] <= a[3]
+}
+
+func xsFeatureName(reach reachGeometry, xs xsGeometry) string {
+	return fmt.Sprintf("%s, %s", reach.name(), xs.Name)
+}
+
+// checkXSCrossings finds cross-section cut lines that cross one another, including across reaches
+func checkXSCrossings(reaches []reachGeometry) []QAFinding {
+	findings := []QAFinding{}
+
+	type cutLine struct {
+		name string
+		line [][2]float64
+		bbox [4]float64
+	}
+	cutLines := []cutLine{}
+	for _, reach := range reaches {
+		for _, xs := range reach.XS {
+			if len(xs.CutLine) < 2 {
+				continue
+			}
+			cutLines = append(cutLines, cutLine{xsFeatureName(reach, xs), xs.CutLine, bbox(xs.CutLine)})
+		}
+	}
+
+	for i := 0; i < len(cutLines); i++ {
+		for j := i + 1; j < len(cutLines); j++ {
+			if !bboxOverlap(cutLines[i].bbox, cutLines[j].bbox) {
+				continue
+			}
+			for _, x := range lineIntersections(cutLines[i].line, cutLines[j].line) {
+				findings = append(findings, QAFinding{
+					Check:    "XS Crossing",
+					Feature:  cutLines[i].name,
+					Message:  fmt.Sprintf("cut line crosses %s", cutLines[j].name),
+					Location: x.point,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkCenterlineIntersections finds cut lines that do not cross their reach's centerline or cross it more
+// than once, and cut lines that are not drawn from left to right looking downstream
+func checkCenterlineIntersections(reaches []reachGeometry) []QAFinding {
+	findings := []QAFinding{}
+	for _, reach := range reaches {
+		if len(reach.Centerline) < 2 {
+			continue
+		}
+		for _, xs := range reach.XS {
+			if len(xs.CutLine) < 2 {
+				continue
+			}
+			name := xsFeatureName(reach, xs)
+			intersections := lineIntersections(xs.CutLine, reach.Centerline)
+
+			switch {
+			case len(intersections) == 0:
+				findings = append(findings, QAFinding{
+					Check:    "Cut Line Centerline Intersection",
+					Feature:  name,
+					Message:  "cut line does not intersect the river centerline",
+					Location: xs.CutLine[0],
+				})
+				continue
+
+			case len(intersections) > 1:
+				findings = append(findings, QAFinding{
+					Check:    "Cut Line Centerline Intersection",
+					Feature:  name,
+					Message:  fmt.Sprintf("cut line intersects the river centerline %d times", len(intersections)),
+					Location: intersections[1].point,
+				})
+			}
+
+			// Looking downstream the cut line should run from the left bank to the right bank, so the
+			// cross product of the centerline and cut line directions is negative where they meet
+			x := intersections[0]
+			cl := segmentDirection(reach.Centerline, x.measureB)
+			cut := segmentDirection(xs.CutLine, x.measureA)
+			if cl[0]*cut[1]-cl[1]*cut[0] > 0 {
+				findings = append(findings, QAFinding{
+					Check:    "Cut Line Direction",
+					Feature:  name,
+					Message:  "cut line is drawn from right to left looking downstream",
+					Location: x.point,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// segmentDirection returns the direction of the segment of a line at a distance along it
+func segmentDirection(xyPairs [][2]float64, d float64) [2]float64 {
+	length := 0.0
+	for i := 1; i < len(xyPairs); i++ {
+		length += distance(xyPairs[i-1], xyPairs[i])
+		if length >= d || i == len(xyPairs)-1 {
+			return [2]float64{xyPairs[i][0] - xyPairs[i-1][0], xyPairs[i][1] - xyPairs[i-1][1]}
+		}
+	}
+	return [2]float64{}
+}
+
+// checkStationOrder finds cross-sections whose river station does not decrease from the one upstream of it
+func checkStationOrder(reaches []reachGeometry) []QAFinding {
+	findings := []QAFinding{}
+	for _, reach := range reaches {
+		for i := 1; i < len(reach.XS); i++ {
+			us, ds := reach.XS[i-1], reach.XS[i]
+			if ds.Station < us.Station {
+				continue
+			}
+			finding := QAFinding{
+				Check:   "River Station Order",
+				Feature: xsFeatureName(reach, ds),
+				Message: fmt.Sprintf("river station %s is not less than the upstream river station %s", ds.Name, us.Name),
+			}
+			if len(ds.CutLine) > 0 {
+				finding.Location = ds.CutLine[0]
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// checkStorageAreas finds storage area and 2D flow area perimeters whose edges cross one another
+func checkStorageAreas(storageAreas []storageAreaGeometry) []QAFinding {
+	findings := []QAFinding{}
+	for _, sa := range storageAreas {
+		ring := sa.Perimeter
+		if len(ring) < 3 {
+			continue
+		}
+		if ring[0] != ring[len(ring)-1] {
+			ring = append(append([][2]float64{}, ring...), ring[0])
+		}
+
+		nSegments := len(ring) - 1
+	segments:
+		for i := 0; i < nSegments; i++ {
+			for j := i + 2; j < nSegments; j++ {
+				if i == 0 && j == nSegments-1 {
+					continue
+				}
+				point, _, _, ok := segmentIntersection(ring[i], ring[i+1], ring[j], ring[j+1])
+				if ok {
+					findings = append(findings, QAFinding{
+						Check:    "Storage Area Self-Intersection",
+						Feature:  sa.Name,
+						Message:  "perimeter intersects itself",
+						Location: point,
+					})
+					break segments
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// SpatialQA checks the cross-sections, river centerlines and storage areas of each geometry file and
+// returns the problems found
+func (rm *RasModel) SpatialQA(destinationCRS int) ([]QAFinding, error) {
+	findings := []QAFinding{}
+
+	if rm.Metadata.Projection == "" {
+		return findings, errors.New("no valid coordinate reference system")
+	}
+
+	transform, err := getTransform(rm.Metadata.Projection, destinationCRS)
+	if err != nil {
+		return findings, err
+	}
+	defer transform.Destroy()
+
+	for _, g := range rm.Metadata.GeomFiles {
+		reaches, err := readXSGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return findings, err
+		}
+		storageAreas, err := readStorageAreaGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return findings, err
+		}
+
+		geomFindings := checkXSCrossings(reaches)
+		geomFindings = append(geomFindings, checkCenterlineIntersections(reaches)...)
+		geomFindings = append(geomFindings, checkStationOrder(reaches)...)
+		geomFindings = append(geomFindings, checkStorageAreas(storageAreas)...)
+
+		for _, finding := range geomFindings {
+			finding.GeomFile = filepath.Base(g.Path)
+			if finding.Location == [2]float64{} {
+				findings = append(findings, finding)
+				continue
+			}
+
+			xyPoint := gdal.Create(gdal.GT_Point)
+			xyPoint.AddPoint2D(finding.Location[0], finding.Location[1])
+			xyPoint.Transform(transform)
+			x, y, _ := xyPoint.Point(0)
+			finding.Location = [2]float64{x, y}
+			xyPoint.Destroy()
+
+			findings = append(findings, finding)
+		}
+	}
+	return findings, nil
+}
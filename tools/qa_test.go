@@ -0,0 +1,46 @@
+package tools
+
+import "testing"
+
+func TestLineIntersections(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b [][2]float64
+		want [][2]float64
+	}{
+		{"single crossing", [][2]float64{{-1, 0}, {1, 0}}, [][2]float64{{0, -1}, {0, 1}}, [][2]float64{{0, 0}}},
+		{"no crossing", [][2]float64{{-1, 0}, {1, 0}}, [][2]float64{{2, -1}, {2, 1}}, [][2]float64{}},
+		{"crossing at a shared vertex of a", [][2]float64{{-1, 0}, {0, 0}, {1, 0}}, [][2]float64{{0, -1}, {0, 1}},
+			[][2]float64{{0, 0}}},
+		{"crossing at shared vertices of both lines", [][2]float64{{-1, 0}, {0, 0}, {1, 0}}, [][2]float64{{0, -1}, {0, 0}, {0, 1}},
+			[][2]float64{{0, 0}}},
+		{"two crossings ordered along a", [][2]float64{{-2, 0}, {2, 0}}, [][2]float64{{1, -1}, {1, 1}, {-1, 1}, {-1, -1}},
+			[][2]float64{{-1, 0}, {1, 0}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			intersections := lineIntersections(tc.a, tc.b)
+			if len(intersections) != len(tc.want) {
+				t.Fatalf("got %d intersections, want %d", len(intersections), len(tc.want))
+			}
+			for i, x := range intersections {
+				if distance(x.point, tc.want[i]) > 1e-9 {
+					t.Errorf("intersection %d is %v, want %v", i, x.point, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckXSCrossings(t *testing.T) {
+	reach := reachGeometry{River: "Creek", Reach: "Main", XS: []xsGeometry{
+		{Name: "300", CutLine: [][2]float64{{-100, 0}, {0, 0}, {100, 0}}},
+		{Name: "200", CutLine: [][2]float64{{0, -100}, {0, 0}, {0, 100}}},
+		{Name: "100", CutLine: [][2]float64{{-100, -200}, {100, -200}}},
+	}}
+	findings := checkXSCrossings([]reachGeometry{reach})
+	if len(findings) != 1 || findings[0].Feature != "Creek, Main, 300" || findings[0].Location != [2]float64{0, 0} {
+		t.Errorf("got %+v, want a single crossing of 300 and 200 at the origin", findings)
+	}
+}
@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RasMapContents keywords and data container for the RAS Mapper file
+type RasMapContents struct {
+	Path           string
+	Version        string
+	ProjectionFile MapLayer
+	Terrains       []MapLayer
+	LandCover      []MapLayer
+	Infiltration   []MapLayer
+	MapResults     []MapLayer
+	Notes          string
+}
+
+// MapLayer is a RAS Mapper layer and the file it references
+type MapLayer struct {
+	Name     string
+	Type     string
+	Filename string
+	Exists   bool
+}
+
+// rasMapPath resolves a path referenced in a RAS Mapper file relative to the model directory
+func rasMapPath(modelDirectory string, fn string) string {
+	p := strings.ReplaceAll(strings.TrimSpace(fn), `\`, "/")
+	if p == "" || filepath.IsAbs(p) || (len(p) > 1 && p[1] == ':') {
+		return p
+	}
+	return filepath.Join(modelDirectory, p)
+}
+
+func xmlAttr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// addMapLayer sorts a layer into its group using the layer type, falling back on the parent element
+func addMapLayer(meta *RasMapContents, layer MapLayer, parent string) {
+	t := strings.ToLower(layer.Type)
+	p := strings.ToLower(parent)
+	switch {
+	case strings.Contains(t, "terrain") || p == "terrains":
+		meta.Terrains = append(meta.Terrains, layer)
+
+	case strings.Contains(t, "landcover") || strings.Contains(p, "landcover"):
+		meta.LandCover = append(meta.LandCover, layer)
+
+	case strings.Contains(t, "infiltration") || strings.Contains(t, "soil") || strings.Contains(p, "infiltration"):
+		meta.Infiltration = append(meta.Infiltration, layer)
+
+	case strings.HasPrefix(t, "rasresults") || p == "results":
+		meta.MapResults = append(meta.MapResults, layer)
+	}
+}
+
+// getRasMapData Reads a RAS Mapper file. returns none to allow concurrency
+func getRasMapData(rm *RasModel, fn string, wg *sync.WaitGroup) {
+
+	defer wg.Done()
+
+	meta := RasMapContents{Path: fn}
+
+	var err error
+	msg := fmt.Sprintf("%s failed to process.", filepath.Base(fn))
+	defer func() {
+		meta.Notes += msg
+		rm.Metadata.RasMapFile = meta
+		if err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	d := xml.NewDecoder(f)
+	d.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	elements := []string{}
+	layers := []MapLayer{}
+	for {
+		var tok xml.Token
+		tok, err = d.Token()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "RASProjectionFilename":
+				meta.ProjectionFile = MapLayer{Name: "Projection", Type: t.Name.Local, Filename: rasMapPath(rm.ModelDirectory, xmlAttr(t, "Filename"))}
+
+			case "Layer":
+				layers = append(layers, MapLayer{Name: xmlAttr(t, "Name"), Type: xmlAttr(t, "Type"), Filename: rasMapPath(rm.ModelDirectory, xmlAttr(t, "Filename"))})
+
+			case "MapParameters":
+				if stored := xmlAttr(t, "StoredFilename"); stored != "" && len(layers) > 0 {
+					layers[len(layers)-1].Filename = rasMapPath(rm.ModelDirectory, stored)
+				}
+			}
+			elements = append(elements, t.Name.Local)
+
+		case xml.EndElement:
+			elements = elements[:len(elements)-1]
+			if t.Name.Local == "Layer" {
+				layer := layers[len(layers)-1]
+				layers = layers[:len(layers)-1]
+				parent := ""
+				if len(elements) > 0 {
+					parent = elements[len(elements)-1]
+				}
+				addMapLayer(&meta, layer, parent)
+			}
+
+		case xml.CharData:
+			if len(elements) == 2 && elements[1] == "Version" {
+				meta.Version = strings.TrimSpace(string(t))
+			}
+		}
+	}
+
+	if meta.ProjectionFile.Filename != "" {
+		meta.ProjectionFile.Exists = fileExists(rm.FileStore, meta.ProjectionFile.Filename)
+	}
+	for _, group := range [][]MapLayer{meta.Terrains, meta.LandCover, meta.Infiltration, meta.MapResults} {
+		for i := range group {
+			if group[i].Filename != "" {
+				group[i].Exists = fileExists(rm.FileStore, group[i].Filename)
+			}
+		}
+	}
+
+	msg = ""
+	return
+}
+
+// supplementalPaths lists the files referenced by the RAS Mapper file that exist in the FileStore
+func (rmc RasMapContents) supplementalPaths() []string {
+	paths := []string{}
+	if rmc.Path == "" {
+		return paths
+	}
+	paths = append(paths, rmc.Path)
+	if rmc.ProjectionFile.Exists {
+		paths = append(paths, rmc.ProjectionFile.Filename)
+	}
+	for _, group := range [][]MapLayer{rmc.Terrains, rmc.LandCover, rmc.Infiltration, rmc.MapResults} {
+		for _, layer := range group {
+			if layer.Exists {
+				paths = append(paths, layer.Filename)
+			}
+		}
+	}
+	return paths
+}
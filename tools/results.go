@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/USACE/mcat-ras/hdf"
+)
+
+// result groups of the plan HDF files. Values are stored with one row per profile or time step and one column per
+// location. The cross-section columns are listed in the geometry info group of the results.
+const (
+	steadyProfiles       string = "/Results/Steady/Output/Output Blocks/Base Output/Steady Profiles/"
+	steadyXSResults      string = steadyProfiles + "Cross Sections/"
+	unsteadyXSSummary    string = "/Results/Unsteady/Output/Output Blocks/Base Output/Summary Output/Cross Sections/"
+	unsteadyTimeSeries   string = "/Results/Unsteady/Output/Output Blocks/Base Output/Unsteady Time Series/"
+	steadyGeometryInfo   string = "/Results/Steady/Output/Geometry Info/"
+	unsteadyGeometryInfo string = "/Results/Unsteady/Output/Geometry Info/"
+)
+
+// planResults is a plan with the geometry and flow files it was run with and its results HDF file
+type planResults struct {
+	Plan     PlanFileContents
+	Geom     GeomFileContents
+	Flow     FlowFileContents
+	HDFFile  string
+	Steady   bool
+	Profiles []string
+}
+
+// getPlanResults finds a plan by file name or extension along with its geometry, flow and results files
+func (rm *RasModel) getPlanResults(planFile string) (planResults, error) {
+	pr := planResults{}
+
+	found := false
+	for _, p := range rm.Metadata.PlanFiles {
+		if filepath.Base(p.Path) == planFile || p.FileExt == planFile || p.FileExt == "."+planFile {
+			pr.Plan, found = p, true
+			break
+		}
+	}
+	if !found {
+		return pr, fmt.Errorf("the plan %s was not found", planFile)
+	}
+
+	geomExt, flowExt := "."+strings.TrimSpace(pr.Plan.GeomFile), "."+strings.TrimSpace(pr.Plan.FlowFile)
+	for _, g := range rm.Metadata.GeomFiles {
+		if g.FileExt == geomExt {
+			pr.Geom = g
+		}
+	}
+	for _, f := range rm.Metadata.FlowFiles {
+		if f.FileExt == flowExt {
+			pr.Flow = f
+		}
+	}
+	if pr.Geom.Path == "" || pr.Flow.Path == "" {
+		return pr, fmt.Errorf("the geometry or flow file of the plan %s was not found", planFile)
+	}
+	pr.Steady = rasRE.Steady.MatchString(flowExt)
+
+	for _, fp := range rm.FileList {
+		if filepath.Base(fp) == filepath.Base(pr.Plan.Path)+".hdf" {
+			pr.HDFFile = fp
+			break
+		}
+	}
+	if pr.HDFFile == "" {
+		return pr, fmt.Errorf("no results were found for the plan %s", planFile)
+	}
+
+	pr.Profiles = []string{}
+	for _, name := range strings.Split(pr.Flow.ProfileNames, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			pr.Profiles = append(pr.Profiles, name)
+		}
+	}
+	return pr, nil
+}
+
+// readResults reads result datasets from a plan HDF file by path. The values of each dataset are returned by row,
+// with a row per profile, summary value or time step. One dimensional datasets are returned as a single row and
+// datasets that are not in the file are left out.
+func readResults(h hdfReader, paths ...string) (map[string][][]float64, error) {
+	results := map[string][][]float64{}
+
+	for _, path := range paths {
+		values, shape, err := h.ReadFloats(path)
+		if errors.Is(err, hdf.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return results, err
+		}
+		rows, cols := 1, len(values)
+		if len(shape) > 1 && shape[0] > 0 {
+			rows, cols = shape[0], len(values)/shape[0]
+		}
+		results[path] = [][]float64{}
+		for r := 0; r < rows && cols > 0; r++ {
+			results[path] = append(results[path], values[r*cols:(r+1)*cols])
+		}
+	}
+	return results, nil
+}
+
+// readProfileNames returns the profile names of a steady plan's results, or those of its flow file when the results
+// do not list them
+func readProfileNames(h hdfReader, pr planResults) ([]string, error) {
+	names, err := h.ReadStrings(steadyProfiles+"Profile Names", "")
+	if errors.Is(err, hdf.ErrNotFound) {
+		return pr.Profiles, nil
+	}
+	return names, err
+}
+
+// profileName returns the name of a row of steady results, numbering rows without a profile name
+func profileName(profiles []string, row int) string {
+	if row < len(profiles) && profiles[row] != "" {
+		return profiles[row]
+	}
+	return fmt.Sprintf("PF %d", row+1)
+}
+
+// xsKey identifies a cross-section by river, reach and station, ignoring the marks of interpolated and edited
+// stations
+func xsKey(river string, reach string, station string) string {
+	if num, err := toNumeric(station); err == nil {
+		station = num
+	}
+	return strings.TrimSpace(river) + "|" + strings.TrimSpace(reach) + "|" + station
+}
+
+// readXSAttributes returns the river, reach and station of each cross-section column of a plan's results, read from
+// the cross-section attributes of its geometry info
+func readXSAttributes(h hdfReader, steady bool) ([][3]string, error) {
+	path := unsteadyGeometryInfo + "Cross Section Attributes"
+	if steady {
+		path = steadyGeometryInfo + "Cross Section Attributes"
+	}
+
+	fields := [][]string{}
+	for _, field := range []string{"River", "Reach", "Station"} {
+		values, err := h.ReadStrings(path, field)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, values)
+	}
+
+	attributes := make([][3]string, len(fields[0]))
+	for i := range attributes {
+		attributes[i] = [3]string{fields[0][i], fields[1][i], fields[2][i]}
+	}
+	return attributes, nil
+}
+
+// readXSColumns returns the key of each cross-section column of a plan's results
+func readXSColumns(h hdfReader, steady bool) ([]string, error) {
+	attributes, err := readXSAttributes(h, steady)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(attributes))
+	for i, a := range attributes {
+		keys[i] = xsKey(a[0], a[1], a[2])
+	}
+	return keys, nil
+}
+
+// joinXSResults orders a row of cross-section results by the cross-sections of each reach, matched to the result
+// columns by river, reach and station. Cross-sections without results are NaN.
+func joinXSResults(reaches []reachGeometry, columns []string, row []float64) [][]float64 {
+	byKey := map[string]float64{}
+	for i, key := range columns {
+		if i < len(row) {
+			byKey[key] = row[i]
+		}
+	}
+
+	joined := make([][]float64, len(reaches))
+	for r, reach := range reaches {
+		joined[r] = make([]float64, len(reach.XS))
+		for i, xs := range reach.XS {
+			value, ok := byKey[xsKey(reach.River, reach.Reach, xs.Name)]
+			if !ok {
+				value = math.NaN()
+			}
+			joined[r][i] = value
+		}
+	}
+	return joined
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"math"
+	"testing"
+)
+
+func TestXSKey(t *testing.T) {
+	tests := []struct {
+		river, reach, station string
+		want                  string
+	}{
+		{"Creek ", " Main", "1500", "Creek|Main|1500"},
+		{"Creek", "Main", "1250.5*", "Creek|Main|1250.5"},
+	}
+	for _, tc := range tests {
+		if got := xsKey(tc.river, tc.reach, tc.station); got != tc.want {
+			t.Errorf("xsKey(%q, %q, %q) = %q, want %q", tc.river, tc.reach, tc.station, got, tc.want)
+		}
+	}
+}
+
+func TestJoinXSResults(t *testing.T) {
+	reaches := []reachGeometry{
+		{River: "Creek", Reach: "Upper", XS: []xsGeometry{{Name: "300"}, {Name: "200"}}},
+		{River: "Creek", Reach: "Lower", XS: []xsGeometry{{Name: "100"}, {Name: "50*"}}},
+	}
+	// the results list the lower reach first and have no column for station 200
+	columns := []string{"Creek|Lower|100", "Creek|Lower|50", "Creek|Upper|300"}
+	row := []float64{10, 9, 12}
+
+	got := joinXSResults(reaches, columns, row)
+	want := [][]float64{{12, math.NaN()}, {10, 9}}
+	for r := range want {
+		for i := range want[r] {
+			if got[r][i] != want[r][i] && !(math.IsNaN(got[r][i]) && math.IsNaN(want[r][i])) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestProfileName(t *testing.T) {
+	profiles := []string{"10yr", ""}
+	tests := []struct {
+		row  int
+		want string
+	}{{0, "10yr"}, {1, "PF 2"}, {2, "PF 3"}}
+	for _, tc := range tests {
+		if got := profileName(profiles, tc.row); got != tc.want {
+			t.Errorf("profileName(%d) = %q, want %q", tc.row, got, tc.want)
+		}
+	}
+}
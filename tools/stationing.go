@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/USACE/filestore"
+	"github.com/dewberry/gdal"
+)
+
+// StationingTolerance is the default fraction by which the ratio of the river station difference of two
+// cross-sections to the distance measured between them along the centerline may differ from the ratio over the
+// whole reach before it is reported
+const StationingTolerance float64 = 0.1
+
+// ISO WKB geometry types of measured line strings
+const (
+	wkbLineStringM      uint32 = 2002
+	wkbMultiLineStringM uint32 = 2005
+)
+
+// StationingFinding is a pair of adjacent cross-sections whose river stations disagree with the distance
+// between them along the river centerline. The reach ratio is the river station difference per unit of
+// centerline length over the reach, which accounts for stationing in miles or kilometres, and the difference
+// is the stated distance minus the measured distance at that ratio.
+type StationingFinding struct {
+	GeomFile         string
+	River            string
+	Reach            string
+	UpstreamXS       string  `json:"Upstream XS"`
+	DownstreamXS     string  `json:"Downstream XS"`
+	StatedDistance   float64 `json:"Stated Distance"`
+	MeasuredDistance float64 `json:"Measured Distance"`
+	ReachRatio       float64 `json:"Reach Ratio"`
+	Difference       float64
+}
+
+// stationControlPoint is a river station located at a distance along the river centerline
+type stationControlPoint struct {
+	name     string
+	distance float64
+	station  float64
+	point    [2]float64
+}
+
+// stationControlPoints locates each cross-section's river station where its cut line first crosses the
+// centerline, ordered from upstream to downstream
+func stationControlPoints(reach reachGeometry) []stationControlPoint {
+	points := []stationControlPoint{}
+	if len(reach.Centerline) < 2 {
+		return points
+	}
+	for _, xs := range reach.XS {
+		if len(xs.CutLine) < 2 {
+			continue
+		}
+		intersections := lineIntersections(xs.CutLine, reach.Centerline)
+		if len(intersections) == 0 {
+			continue
+		}
+		points = append(points, stationControlPoint{xs.Name, intersections[0].measureB, xs.Station, intersections[0].point})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].distance < points[j].distance })
+	return points
+}
+
+// stationAt interpolates the river station at a distance along the centerline between the control points,
+// extending the nearest interval beyond the first and last cross-sections. With a single control point
+// stations decrease downstream at the rate of the centerline units, and without one the station is the
+// distance remaining to the downstream end.
+func stationAt(controlPoints []stationControlPoint, d float64, length float64) float64 {
+	switch len(controlPoints) {
+	case 0:
+		return length - d
+	case 1:
+		return controlPoints[0].station - (d - controlPoints[0].distance)
+	}
+
+	i := sort.Search(len(controlPoints), func(i int) bool { return controlPoints[i].distance >= d })
+	if i == 0 {
+		i = 1
+	}
+	if i == len(controlPoints) {
+		i = len(controlPoints) - 1
+	}
+	us, ds := controlPoints[i-1], controlPoints[i]
+	if ds.distance == us.distance {
+		return us.station
+	}
+	return us.station + (ds.station-us.station)*(d-us.distance)/(ds.distance-us.distance)
+}
+
+// measureCenterline returns the centerline vertices with the cross-section intersections added, each
+// measured with its river station
+func measureCenterline(reach reachGeometry) [][3]float64 {
+	controlPoints := stationControlPoints(reach)
+
+	type vertex struct {
+		distance float64
+		point    [2]float64
+	}
+	vertices := []vertex{{0, reach.Centerline[0]}}
+	length := 0.0
+	for i := 1; i < len(reach.Centerline); i++ {
+		length += distance(reach.Centerline[i-1], reach.Centerline[i])
+		vertices = append(vertices, vertex{length, reach.Centerline[i]})
+	}
+	for _, cp := range controlPoints {
+		vertices = append(vertices, vertex{cp.distance, cp.point})
+	}
+	sort.SliceStable(vertices, func(i, j int) bool { return vertices[i].distance < vertices[j].distance })
+
+	xym := [][3]float64{}
+	for i, v := range vertices {
+		if i > 0 && v.point == vertices[i-1].point {
+			continue
+		}
+		xym = append(xym, [3]float64{v.point[0], v.point[1], stationAt(controlPoints, v.distance, length)})
+	}
+	return xym
+}
+
+// checkStationing compares the ratio of the river station difference to the centerline distance of each pair of
+// adjacent cross-sections with the ratio between the first and last cross-sections of the reach
+func checkStationing(reach reachGeometry, tolerance float64) []StationingFinding {
+	findings := []StationingFinding{}
+	controlPoints := stationControlPoints(reach)
+	if len(controlPoints) < 2 {
+		return findings
+	}
+
+	first, last := controlPoints[0], controlPoints[len(controlPoints)-1]
+	if last.distance <= first.distance {
+		return findings
+	}
+	reachRatio := (first.station - last.station) / (last.distance - first.distance)
+
+	for i := 1; i < len(controlPoints); i++ {
+		us, ds := controlPoints[i-1], controlPoints[i]
+		stated := us.station - ds.station
+		measured := ds.distance - us.distance
+		if math.Abs(stated-reachRatio*measured) <= tolerance*math.Abs(reachRatio)*measured {
+			continue
+		}
+		findings = append(findings, StationingFinding{
+			River:            reach.River,
+			Reach:            reach.Reach,
+			UpstreamXS:       us.name,
+			DownstreamXS:     ds.name,
+			StatedDistance:   stated,
+			MeasuredDistance: measured,
+			ReachRatio:       reachRatio,
+			Difference:       stated - reachRatio*measured,
+		})
+	}
+	return findings
+}
+
+// multiLineStringMWKB encodes a single measured line as little endian ISO WKB, since the gdal bindings
+// do not support measured geometries
+func multiLineStringMWKB(xym [][3]float64) ([]uint8, error) {
+	var buf bytes.Buffer
+	for _, v := range []interface{}{uint8(1), wkbMultiLineStringM, uint32(1), uint8(1), wkbLineStringM, uint32(len(xym))} {
+		if err := binary.Write(&buf, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range xym {
+		if err := binary.Write(&buf, binary.LittleEndian, p); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// getMeasuredCenterlines replaces each river centerline with a line measured in river stations and records the
+// number of cross-sections used to calibrate it
+func getMeasuredCenterlines(f *Features, fs filestore.FileStore, geomFilePath string, transform gdal.CoordinateTransform) error {
+	reaches, err := readXSGeometry(fs, geomFilePath)
+	if err != nil {
+		return err
+	}
+
+	riverLayers := map[string]*VectorLayer{}
+	for i := range f.Rivers {
+		riverLayers[f.Rivers[i].FeatureName] = &f.Rivers[i]
+	}
+
+	for _, reach := range reaches {
+		layer, ok := riverLayers[reach.name()]
+		if !ok || len(reach.Centerline) < 2 {
+			continue
+		}
+
+		xym := measureCenterline(reach)
+		xyLineString := gdal.Create(gdal.GT_LineString)
+		for _, p := range xym {
+			xyLineString.AddPoint2D(p[0], p[1])
+		}
+		xyLineString.Transform(transform)
+		for i := range xym {
+			xym[i][0], xym[i][1], _ = xyLineString.Point(i)
+		}
+		xyLineString.Destroy()
+
+		wkb, err := multiLineStringMWKB(xym)
+		if err != nil {
+			return err
+		}
+		layer.Geometry = wkb
+		layer.Fields = map[string]interface{}{
+			"RiverReachName":    reach.name(),
+			"UpstreamStation":   xym[0][2],
+			"DownstreamStation": xym[len(xym)-1][2],
+			"StationControlXS":  len(stationControlPoints(reach)),
+		}
+	}
+	return nil
+}
+
+// StationingReport returns the adjacent cross-sections of each geometry file whose river station difference per
+// unit of centerline length differs from that of their reach by more than the tolerance, given as a fraction
+func (rm *RasModel) StationingReport(tolerance float64) ([]StationingFinding, error) {
+	findings := []StationingFinding{}
+	if tolerance < 0 {
+		return findings, errors.New("tolerance must not be negative")
+	}
+
+	for _, g := range rm.Metadata.GeomFiles {
+		reaches, err := readXSGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return findings, err
+		}
+		for _, reach := range reaches {
+			for _, finding := range checkStationing(reach, tolerance) {
+				finding.GeomFile = filepath.Base(g.Path)
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"math"
+	"testing"
+)
+
+// straightReach returns a reach along the y axis flowing north to south with a cross-section crossing the
+// centerline at each distance, named by its station
+func straightReach(distances []float64, stations []float64) reachGeometry {
+	reach := reachGeometry{River: "Creek", Reach: "Main", Centerline: [][2]float64{{0, 10000}, {0, 0}}}
+	for i, d := range distances {
+		y := 10000 - d
+		reach.XS = append(reach.XS, xsGeometry{Name: formatFloat(stations[i]), Station: stations[i],
+			CutLine: [][2]float64{{-100, y}, {100, y}}})
+	}
+	return reach
+}
+
+func TestCheckStationing(t *testing.T) {
+	tests := []struct {
+		name      string
+		distances []float64
+		stations  []float64
+		want      []string
+	}{
+		{"stations in feet", []float64{0, 500, 1000, 1500}, []float64{1500, 1000, 500, 0}, []string{}},
+		{"stations in miles", []float64{0, 1320, 2640, 3960}, []float64{10.75, 10.5, 10.25, 10}, []string{}},
+		{"stations in kilometres", []float64{0, 1000, 2000}, []float64{3, 2, 1}, []string{}},
+		{"mistyped station in miles", []float64{0, 1320, 2640, 3960, 5280}, []float64{11, 10.75, 10.4, 10.25, 10},
+			[]string{"10.75", "10.4"}},
+		{"misplaced cross-section in feet", []float64{0, 500, 1500, 2000}, []float64{2000, 1500, 1000, 0}, []string{"1500", "1000"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := checkStationing(straightReach(tc.distances, tc.stations), StationingTolerance)
+			got := []string{}
+			for _, f := range findings {
+				got = append(got, f.UpstreamXS)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("got findings upstream of %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("got findings upstream of %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestStationAt(t *testing.T) {
+	controlPoints := []stationControlPoint{{"10", 0, 10, [2]float64{}}, {"9", 5280, 9, [2]float64{}}}
+	tests := []struct {
+		d    float64
+		want float64
+	}{{0, 10}, {2640, 9.5}, {5280, 9}, {7920, 8.5}, {-2640, 10.5}}
+	for _, tc := range tests {
+		if got := stationAt(controlPoints, tc.d, 7920); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("stationAt(%v) = %v, want %v", tc.d, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+// XSResult is the result of a steady profile at a cross-section
+type XSResult struct {
+	WSE         float64 `json:"Water Surface Elevation"`
+	EnergyGrade float64 `json:"Energy Grade"`
+	Velocity    float64
+	Flow        float64
+	TopWidth    float64 `json:"Top Width"`
+	Froude      float64 `json:"Froude Number"`
+}
+
+// steadyXSVariables are the plan HDF datasets read into each field of a cross-section result, with the power of the
+// length factor that converts them
+var steadyXSVariables = []struct {
+	group   string
+	dataset string
+	power   int
+	field   func(r *XSResult) *float64
+}{
+	{steadyXSResults, "Water Surface", 1, func(r *XSResult) *float64 { return &r.WSE }},
+	{steadyXSResults, "Energy Grade", 1, func(r *XSResult) *float64 { return &r.EnergyGrade }},
+	{steadyXSResults, "Flow", 3, func(r *XSResult) *float64 { return &r.Flow }},
+	{steadyXSResults + "Additional Variables/", "Velocity Total", 1, func(r *XSResult) *float64 { return &r.Velocity }},
+	{steadyXSResults + "Additional Variables/", "Top Width Total", 1, func(r *XSResult) *float64 { return &r.TopWidth }},
+	{steadyXSResults + "Additional Variables/", "Froude # Channel", 0, func(r *XSResult) *float64 { return &r.Froude }},
+}
+
+// readSteadyXSResults returns the results of each profile of a steady plan by cross-section key, with the datasets
+// that were not found in the results
+func (rm *RasModel) readSteadyXSResults(pr planResults, lengthFactor float64) (map[string]map[string]XSResult, []string, error) {
+	xsResults := map[string]map[string]XSResult{}
+	missing := []string{}
+
+	paths := []string{}
+	for _, v := range steadyXSVariables {
+		paths = append(paths, v.group+v.dataset)
+	}
+	h, err := openHDF(rm.FileStore, pr.HDFFile)
+	if err != nil {
+		return xsResults, missing, err
+	}
+	defer h.Close()
+	results, err := readResults(h, paths...)
+	if err != nil {
+		return xsResults, missing, err
+	}
+	columns, err := readXSColumns(h, true)
+	if err != nil {
+		return xsResults, missing, err
+	}
+	profiles, err := readProfileNames(h, pr)
+	if err != nil {
+		return xsResults, missing, err
+	}
+	for _, key := range columns {
+		xsResults[key] = map[string]XSResult{}
+	}
+
+	for _, v := range steadyXSVariables {
+		rows, ok := results[v.group+v.dataset]
+		if !ok {
+			missing = append(missing, v.dataset)
+			continue
+		}
+
+		f := 1.0
+		for p := 0; p < v.power; p++ {
+			f *= lengthFactor
+		}
+		for p, row := range rows {
+			if len(row) != len(columns) {
+				return xsResults, missing, fmt.Errorf("the results of %s do not match its %d cross-section attributes", filepath.Base(pr.Plan.Path), len(columns))
+			}
+			profile := profileName(profiles, p)
+			for i, value := range row {
+				r := xsResults[columns[i]][profile]
+				*v.field(&r) = value * f
+				xsResults[columns[i]][profile] = r
+			}
+		}
+	}
+	return xsResults, missing, nil
+}
+
+// SteadyResults adds the results of each steady plan to the cross-section features of its geometry file. Each
+// cross-section's "SteadyResults" field holds the results of each plan file keyed by profile name, in the model's
+// output units. Plans whose geometry or results cannot be read and results without a variable are reported as
+// warnings.
+func (rm *RasModel) SteadyResults(gd *GeoData) error {
+	lengthFactor, err := rm.lengthFactor()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range rm.Metadata.PlanFiles {
+		if !rasRE.Steady.MatchString("." + strings.TrimSpace(p.FlowFile)) {
+			continue
+		}
+		planFile := filepath.Base(p.Path)
+		pr, err := rm.getPlanResults(planFile)
+		if err != nil {
+			log.Println(err)
+			gd.Warnings = append(gd.Warnings, err.Error())
+			continue
+		}
+
+		features, ok := gd.Features[filepath.Base(pr.Geom.Path)]
+		if !ok {
+			continue
+		}
+
+		reaches, err := readXSGeometry(rm.FileStore, pr.Geom.Path)
+		if err != nil {
+			log.Println(err)
+			gd.Warnings = append(gd.Warnings, err.Error())
+			continue
+		}
+
+		xsResults, missing, err := rm.readSteadyXSResults(pr, lengthFactor)
+		if err != nil {
+			log.Println(err)
+			gd.Warnings = append(gd.Warnings, err.Error())
+			continue
+		}
+		for _, dataset := range missing {
+			msg := fmt.Sprintf("%s was not found in the results of %s", dataset, planFile)
+			log.Println(msg)
+			gd.Warnings = append(gd.Warnings, msg)
+		}
+
+		// cross-section features are keyed by reach and numeric station name
+		index := map[string]map[string]XSResult{}
+		for _, reach := range reaches {
+			for _, xs := range reach.XS {
+				results, ok := xsResults[xsKey(reach.River, reach.Reach, xs.Name)]
+				if !ok {
+					continue
+				}
+				name, err := toNumeric(xs.Name)
+				if err != nil {
+					name = xs.Name
+				}
+				index[reach.name()+"|"+name] = results
+			}
+		}
+
+		for _, layer := range features.XS {
+			results, ok := index[fmt.Sprintf("%v|%s", layer.Fields["RiverReachName"], layer.FeatureName)]
+			if !ok {
+				continue
+			}
+			byPlan, ok := layer.Fields["SteadyResults"].(map[string]map[string]XSResult)
+			if !ok {
+				byPlan = map[string]map[string]XSResult{}
+				layer.Fields["SteadyResults"] = byPlan
+			}
+			byPlan[planFile] = results
+		}
+	}
+	return nil
+}
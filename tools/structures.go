@@ -20,12 +20,15 @@ var conduitShapes map[int]string = map[int]string{
 	9: "Conspan Arch"}
 
 type hydraulicStructures struct {
-	River       string      `json:"River Name"`
-	Reach       string      `json:"Reach Name"`
-	NumXS       int         `json:"Num CrossSections"`
-	CulvertData culvertData `json:"Culvert Data"`
-	BridgeData  bridgeData  `json:"Bridge Data"`
-	WeirData    weirData    `json:"Inline Weir Data"`
+	River       string               `json:"River Name"`
+	Reach       string               `json:"Reach Name"`
+	NumXS       int                  `json:"Num CrossSections"`
+	CulvertData culvertData          `json:"Culvert Data"`
+	BridgeData  bridgeData           `json:"Bridge Data"`
+	WeirData    weirData             `json:"Inline Weir Data"`
+	LateralData lateralStructureData `json:"Lateral Structure Data"`
+	PumpData    pumpStationData      `json:"Pump Station Data"`
+	OutletData  outletData           `json:"Rating Curve Outlet Data"`
 }
 
 type culvertData struct {
@@ -52,13 +55,29 @@ type maxMinPairs struct {
 }
 
 type conduits struct {
-	Name       string
-	NumBarrels int `json:"Num Barrels"`
-	Shape      string
-	Rise       float64
-	Span       float64
-	Length     float64
-	ManningsN  float64 `json:"Mannings N"`
+	Name             string
+	NumBarrels       int `json:"Num Barrels"`
+	Shape            string
+	Rise             float64
+	Span             float64
+	Length           float64
+	ManningsN        float64   `json:"Mannings N"`
+	BottomN          float64   `json:"Bottom Mannings N"`
+	DepthBlocked     float64   `json:"Depth Blocked"`
+	EntranceLossCoef float64   `json:"Entrance Loss Coefficient"`
+	ExitLossCoef     float64   `json:"Exit Loss Coefficient"`
+	ChartNumber      int       `json:"FHWA Chart Number"`
+	ScaleNumber      int       `json:"FHWA Scale Number"`
+	UpInvert         float64   `json:"Upstream Invert"`
+	DownInvert       float64   `json:"Downstream Invert"`
+	Barrels          []barrels `json:"Barrels"`
+}
+
+// barrels is the centerline of a culvert barrel and its slope from the upstream to the downstream invert
+type barrels struct {
+	UpStation   float64 `json:"Upstream Centerline Station"`
+	DownStation float64 `json:"Downstream Centerline Station"`
+	Slope       float64
 }
 
 type bridgeData struct {
@@ -67,15 +86,63 @@ type bridgeData struct {
 }
 
 type bridges struct {
-	Name          string
-	Station       float64
-	Description   string
-	DeckWidth     float64     `json:"Deck Width"`
-	UpHighChord   maxMinPairs `json:"Upstream High Chord"`
-	UpLowChord    maxMinPairs `json:"Upstream Low Chord"`
-	DownHighChord maxMinPairs `json:"Downstream High Chord"`
-	DownLowChord  maxMinPairs `json:"Downstream Low Chord"`
-	NumPiers      int         `json:"Num Piers"`
+	Name           string
+	Station        float64
+	Description    string
+	DeckWidth      float64        `json:"Deck Width"`
+	WeirCoef       float64        `json:"Weir Coefficient"`
+	MaxSubmergence float64        `json:"Max Submergence"`
+	IsOgee         bool           `json:"Is Ogee"`
+	UpHighChord    maxMinPairs    `json:"Upstream High Chord"`
+	UpLowChord     maxMinPairs    `json:"Upstream Low Chord"`
+	DownHighChord  maxMinPairs    `json:"Downstream High Chord"`
+	DownLowChord   maxMinPairs    `json:"Downstream Low Chord"`
+	UpDeck         []deckPoint    `json:"Upstream Deck Roadway"`
+	DownDeck       []deckPoint    `json:"Downstream Deck Roadway"`
+	NumPiers       int            `json:"Num Piers"`
+	Piers          []piers        `json:"Piers"`
+	Abutments      []abutments    `json:"Abutments"`
+	ModelingMethod bridgeModeling `json:"Modeling Approach"`
+}
+
+// deckPoint is a station of the deck/roadway with its high and low chord elevations
+type deckPoint struct {
+	Station   float64
+	HighChord float64 `json:"High Chord"`
+	LowChord  float64 `json:"Low Chord"`
+}
+
+// widthElevation is the width of a pier at an elevation
+type widthElevation struct {
+	Width     float64
+	Elevation float64
+}
+
+type piers struct {
+	Skew        float64
+	UpStation   float64          `json:"Upstream Station"`
+	DownStation float64          `json:"Downstream Station"`
+	UpWidths    []widthElevation `json:"Upstream Widths"`
+	DownWidths  []widthElevation `json:"Downstream Widths"`
+}
+
+type abutments struct {
+	Skew   float64
+	UpSE   [][2]float64 `json:"Upstream Station Elevation"`
+	DownSE [][2]float64 `json:"Downstream Station Elevation"`
+}
+
+// bridgeModeling holds the low flow and high flow methods of a bridge. The low flow methods listed are those
+// computed, and the method used is either one of them or the highest energy answer.
+type bridgeModeling struct {
+	LowFlowMethods    []string `json:"Low Flow Methods"`
+	LowFlowMethodUsed string   `json:"Low Flow Method Used"`
+	MomentumCd        float64  `json:"Momentum Drag Coefficient"`
+	YarnellK          float64  `json:"Yarnell Pier Coefficient"`
+	HighFlowMethod    string   `json:"High Flow Method"`
+	SubmergedInletCd  float64  `json:"Submerged Inlet Coefficient"`
+	InletControlCd    float64  `json:"Inlet Control Coefficient"`
+	MaxLowChord       float64  `json:"Max Low Chord"`
 }
 
 type weirData struct {
@@ -95,11 +162,57 @@ type weirs struct {
 	Conduits    []conduits `json:"Culvert Conduits"`
 }
 
+// gateTypes are the gate types in the order of the RAS gate editor
+var gateTypes map[int]string = map[int]string{
+	1: "Sluice",
+	2: "Radial",
+	3: "Overflow (open air)",
+	4: "Overflow (closed top)",
+	5: "User Defined Curves"}
+
 type gates struct {
+	Name            string
+	Type            string
+	Width           float64
+	Height          float64
+	SillElevation   float64        `json:"Sill Elevation"`
+	DischargeCoef   float64        `json:"Discharge Coefficient"`
+	TrunnionExp     float64        `json:"Trunnion Exponent"`
+	OpeningExp      float64        `json:"Opening Exponent"`
+	HeadExp         float64        `json:"Head Exponent"`
+	WeirCoef        float64        `json:"Weir Coefficient"`
+	IsOgee          bool           `json:"Is Ogee"`
+	SpillwayHeight  float64        `json:"Spillway Height"`
+	DesignHead      float64        `json:"Design Head"`
+	NumOpenings     int            `json:"Num Openings"`
+	OpeningStations []float64      `json:"Opening Centerline Stations"`
+	Openings        []gateOpenings `json:"Gate Openings"`
+}
+
+// gateOpenings is the time series of gate openings given for a gate group in an unsteady flow file
+type gateOpenings struct {
+	FlowFile string `json:"Flow File"`
+	Interval string
+	UseDSS   bool   `json:"Use DSS"`
+	DSSPath  string `json:"DSS Path"`
+	Openings []float64
+}
+
+type lateralStructureData struct {
+	NumLateralStructures int                 `json:"Num Lateral Structures"`
+	LateralStructures    []lateralStructures `json:"Lateral Structures"`
+}
+
+type lateralStructures struct {
 	Name        string
-	Width       float64
-	Height      float64
-	NumOpenings int `json:"Num Openings"`
+	Station     float64
+	Description string
+	WeirWidth   float64     `json:"Weir Width"`
+	WeirElev    maxMinPairs `json:"Weir Elevations"`
+	NumGates    int         `json:"Num Gates"`
+	Gates       []gates
+	NumConduits int        `json:"Num Culvert Conduits"`
+	Conduits    []conduits `json:"Culvert Conduits"`
 }
 
 func datafromTextBlock(hsSc *bufio.Scanner, i int, nLines int, nSkipLines int, colWidth int, valueWidth int, interval int) ([]float64, int, error) {
@@ -202,6 +315,226 @@ func getHighLowChord(hsSc *bufio.Scanner, i int, nElevText string, colWidth int,
 	return highLowPairs, i, nil
 }
 
+// fixedWidthFields reads n fixed width values spread over as many lines as needed, keeping blank values as empty strings
+func fixedWidthFields(hsSc *bufio.Scanner, i int, n int, colWidth int, valueWidth int) ([]string, int) {
+	fields := []string{}
+	nLines := numberofLines(n, colWidth, valueWidth)
+	for l := 0; l < nLines && hsSc.Scan(); l++ {
+		i++
+		line := hsSc.Text()
+		for s := 0; s < colWidth && len(fields) < n; s += valueWidth {
+			if s >= len(line) {
+				fields = append(fields, "")
+				continue
+			}
+			end := s + valueWidth
+			if end > len(line) {
+				end = len(line)
+			}
+			fields = append(fields, strings.TrimSpace(line[s:end]))
+		}
+	}
+	for len(fields) < n {
+		fields = append(fields, "")
+	}
+	return fields, i
+}
+
+// fixedWidthValues reads n fixed width values, with blank values read as zero
+func fixedWidthValues(hsSc *bufio.Scanner, i int, n int, colWidth int, valueWidth int) ([]float64, int, error) {
+	values := []float64{}
+	fields, i := fixedWidthFields(hsSc, i, n, colWidth, valueWidth)
+	for _, field := range fields {
+		val, err := stringtoFloat(field)
+		if err != nil {
+			return values, i, err
+		}
+		values = append(values, val)
+	}
+	return values, i, nil
+}
+
+// maxMinFields returns the largest and smallest of the non-blank values
+func maxMinFields(fields []string) (maxMinPairs, error) {
+	values := []float64{}
+	for _, field := range fields {
+		if field == "" {
+			continue
+		}
+		val, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return maxMinPairs{}, err
+		}
+		values = append(values, val)
+	}
+	if len(values) == 0 {
+		return maxMinPairs{}, nil
+	}
+	maxElev, err := maxValue(values)
+	if err != nil {
+		return maxMinPairs{}, err
+	}
+	minElev, err := minValue(values)
+	if err != nil {
+		return maxMinPairs{}, err
+	}
+	return maxMinPairs{Max: maxElev, Min: minElev}, nil
+}
+
+// getDeckRoadway reads the stations, high chords and low chords of one side of a deck/roadway and the range of
+// its high and low chord elevations
+func getDeckRoadway(hsSc *bufio.Scanner, i int, nPointsText string) ([]deckPoint, [2]maxMinPairs, int, error) {
+	deck := []deckPoint{}
+	highLowPairs := [2]maxMinPairs{}
+
+	n, err := strconv.Atoi(strings.TrimSpace(nPointsText))
+	if err != nil {
+		return deck, highLowPairs, i, err
+	}
+
+	table := [3][]string{}
+	for j := range table {
+		table[j], i = fixedWidthFields(hsSc, i, n, 80, 8)
+	}
+	for j := 0; j < n; j++ {
+		point := deckPoint{}
+		for k, v := range []*float64{&point.Station, &point.HighChord, &point.LowChord} {
+			if *v, err = stringtoFloat(table[k][j]); err != nil {
+				return deck, highLowPairs, i, err
+			}
+		}
+		deck = append(deck, point)
+	}
+
+	for j := range highLowPairs {
+		if highLowPairs[j], err = maxMinFields(table[j+1]); err != nil {
+			return deck, highLowPairs, i, err
+		}
+	}
+	return deck, highLowPairs, i, nil
+}
+
+// getPier reads a pier's stations and its widths at elevations on the upstream and downstream sides from the
+// fields of a "Pier Skew, UpSta & Num, DnSta & Num=" line and the lines that follow
+func getPier(hsSc *bufio.Scanner, i int, lineData []string) (piers, int, error) {
+	pier := piers{}
+	if len(lineData) < 5 {
+		return pier, i, errors.New("the pier definition is incomplete")
+	}
+
+	values := make([]float64, 5)
+	for j := range values {
+		val, err := stringtoFloat(lineData[j])
+		if err != nil {
+			return pier, i, err
+		}
+		values[j] = val
+	}
+	pier.Skew, pier.UpStation, pier.DownStation = values[0], values[1], values[3]
+
+	for _, side := range []struct {
+		n      int
+		widths *[]widthElevation
+	}{{int(values[2]), &pier.UpWidths}, {int(values[4]), &pier.DownWidths}} {
+		widths, i2, err := fixedWidthValues(hsSc, i, side.n, 80, 8)
+		i = i2
+		if err != nil {
+			return pier, i, err
+		}
+		elevations, i2, err := fixedWidthValues(hsSc, i, side.n, 80, 8)
+		i = i2
+		if err != nil {
+			return pier, i, err
+		}
+		*side.widths = []widthElevation{}
+		for j := range widths {
+			*side.widths = append(*side.widths, widthElevation{Width: widths[j], Elevation: elevations[j]})
+		}
+	}
+	return pier, i, nil
+}
+
+// getAbutment reads the upstream and downstream station-elevation points of an abutment from the fields of an
+// "Abutment Skew #Up #Dn=" line and the lines that follow
+func getAbutment(hsSc *bufio.Scanner, i int, lineData []string) (abutments, int, error) {
+	abutment := abutments{}
+	if len(lineData) < 3 {
+		return abutment, i, errors.New("the abutment definition is incomplete")
+	}
+
+	values := make([]float64, 3)
+	for j := range values {
+		val, err := stringtoFloat(lineData[j])
+		if err != nil {
+			return abutment, i, err
+		}
+		values[j] = val
+	}
+	abutment.Skew = values[0]
+
+	for _, side := range []struct {
+		n  int
+		se *[][2]float64
+	}{{int(values[1]), &abutment.UpSE}, {int(values[2]), &abutment.DownSE}} {
+		stations, i2, err := fixedWidthValues(hsSc, i, side.n, 80, 8)
+		i = i2
+		if err != nil {
+			return abutment, i, err
+		}
+		elevations, i2, err := fixedWidthValues(hsSc, i, side.n, 80, 8)
+		i = i2
+		if err != nil {
+			return abutment, i, err
+		}
+		*side.se = [][2]float64{}
+		for j := range stations {
+			*side.se = append(*side.se, [2]float64{stations[j], elevations[j]})
+		}
+	}
+	return abutment, i, nil
+}
+
+var lowFlowMethods []string = []string{"Energy", "Momentum", "Yarnell", "WSPRO"}
+
+// getBridgeModeling reads the bridge modeling approach from the fields of a "BR Coef=" line, given in the order of
+// the Bridge Modeling Approach editor: the low flow methods computed (-1 when computed), the low flow method used
+// (0 for the highest energy answer, otherwise the position of the method), the momentum drag and Yarnell pier
+// coefficients, the high flow method (0 for energy only, 1 for pressure and/or weir), the submerged inlet and
+// inlet control coefficients and the maximum low chord.
+func getBridgeModeling(lineData []string) (bridgeModeling, error) {
+	modeling := bridgeModeling{LowFlowMethods: []string{}}
+
+	values := make([]float64, 11)
+	for j := 0; j < len(values) && j < len(lineData); j++ {
+		val, err := stringtoFloat(lineData[j])
+		if err != nil {
+			return modeling, err
+		}
+		values[j] = val
+	}
+
+	for j, method := range lowFlowMethods {
+		if values[j] != 0 {
+			modeling.LowFlowMethods = append(modeling.LowFlowMethods, method)
+		}
+	}
+
+	modeling.LowFlowMethodUsed = "Highest Energy Answer"
+	if used := int(values[4]); used > 0 && used <= len(lowFlowMethods) {
+		modeling.LowFlowMethodUsed = lowFlowMethods[used-1]
+	}
+
+	modeling.MomentumCd, modeling.YarnellK = values[5], values[6]
+
+	modeling.HighFlowMethod = "Energy Only"
+	if values[7] != 0 {
+		modeling.HighFlowMethod = "Pressure and/or Weir"
+	}
+
+	modeling.SubmergedInletCd, modeling.InletControlCd, modeling.MaxLowChord = values[8], values[9], values[10]
+	return modeling, nil
+}
+
 func stringtoFloat(s string) (float64, error) {
 	trimmed := strings.TrimSpace(s)
 	if trimmed != "" {
@@ -214,54 +547,112 @@ func stringtoFloat(s string) (float64, error) {
 	return 0, nil
 }
 
+// getConduits reads a "Culvert=", "Multiple Barrel Culv=" or "IW Culv=" line. Its fields are the shape, rise, span,
+// length, Manning's n, entrance and exit loss coefficients and FHWA chart and scale numbers, followed for a single
+// culvert by the upstream centerline station and invert, the downstream centerline station and invert and the name,
+// or for multiple barrels by the upstream and downstream inverts, the number of barrels and the name. The bottom n
+// and depth blocked come last.
 func getConduits(line string, single bool) (conduits, error) {
 	lineData := strings.Split(rightofEquals(line), ",")
-	conduit := conduits{}
+	conduit := conduits{Barrels: []barrels{}}
+
+	field := func(n int) string {
+		if n < len(lineData) {
+			return strings.TrimSpace(lineData[n])
+		}
+		return ""
+	}
 
+	var upStation, downStation float64
+	var err error
+	tail := 13
 	if single {
 		conduit.NumBarrels = 1
-		conduit.Name = strings.TrimSpace(lineData[13])
+		conduit.Name = field(13)
+		for _, v := range []struct {
+			n   int
+			val *float64
+		}{{9, &upStation}, {10, &conduit.UpInvert}, {11, &downStation}, {12, &conduit.DownInvert}} {
+			if *v.val, err = stringtoFloat(field(v.n)); err != nil {
+				return conduit, err
+			}
+		}
+		tail = 14
 
 	} else {
-		numbarrels, err := strconv.Atoi(strings.TrimSpace(lineData[11]))
+		numbarrels, err := strconv.Atoi(field(11))
 		if err != nil {
 			return conduit, err
 		}
 		conduit.NumBarrels = numbarrels
-		conduit.Name = strings.TrimSpace(lineData[12])
+		conduit.Name = field(12)
+		for _, v := range []struct {
+			n   int
+			val *float64
+		}{{9, &conduit.UpInvert}, {10, &conduit.DownInvert}} {
+			if *v.val, err = stringtoFloat(field(v.n)); err != nil {
+				return conduit, err
+			}
+		}
 	}
 
-	shapeID, err := strconv.Atoi(strings.TrimSpace(lineData[0]))
+	shapeID, err := strconv.Atoi(field(0))
 	if err != nil {
 		return conduit, err
 	}
 	conduit.Shape = conduitShapes[shapeID]
 
-	rise, err := stringtoFloat(lineData[1])
-	if err != nil {
-		return conduit, err
+	for _, v := range []struct {
+		n   int
+		val *float64
+	}{{1, &conduit.Rise}, {2, &conduit.Span}, {3, &conduit.Length}, {4, &conduit.ManningsN},
+		{5, &conduit.EntranceLossCoef}, {6, &conduit.ExitLossCoef}, {tail, &conduit.BottomN}, {tail + 1, &conduit.DepthBlocked}} {
+		if *v.val, err = stringtoFloat(field(v.n)); err != nil {
+			return conduit, err
+		}
 	}
-	conduit.Rise = rise
 
-	span, err := stringtoFloat(lineData[2])
-	if err != nil {
-		return conduit, err
+	for _, v := range []struct {
+		n   int
+		val *int
+	}{{7, &conduit.ChartNumber}, {8, &conduit.ScaleNumber}} {
+		if field(v.n) == "" {
+			continue
+		}
+		if *v.val, err = strconv.Atoi(field(v.n)); err != nil {
+			return conduit, err
+		}
 	}
-	conduit.Span = span
 
-	length, err := stringtoFloat(lineData[3])
-	if err != nil {
-		return conduit, err
+	if single {
+		conduit.Barrels = append(conduit.Barrels, barrels{UpStation: upStation, DownStation: downStation, Slope: conduit.slope()})
 	}
-	conduit.Length = length
+	return conduit, nil
+}
 
-	mannings, err := stringtoFloat(lineData[4])
-	if err != nil {
-		return conduit, err
+// slope is the fall from the upstream to the downstream invert over the length of the barrels
+func (c conduits) slope() float64 {
+	if c.Length == 0 {
+		return 0
 	}
-	conduit.ManningsN = mannings
+	return (c.UpInvert - c.DownInvert) / c.Length
+}
 
-	return conduit, nil
+// getBarrelStations reads the upstream and then the downstream centerline stations of each barrel, which follow a
+// "Multiple Barrel Culv=" line
+func getBarrelStations(hsSc *bufio.Scanner, i int, conduit *conduits) (int, error) {
+	upStations, i, err := fixedWidthValues(hsSc, i, conduit.NumBarrels, 80, 8)
+	if err != nil {
+		return i, err
+	}
+	downStations, i, err := fixedWidthValues(hsSc, i, conduit.NumBarrels, 80, 8)
+	if err != nil {
+		return i, err
+	}
+	for b := 0; b < conduit.NumBarrels; b++ {
+		conduit.Barrels = append(conduit.Barrels, barrels{UpStation: upStations[b], DownStation: downStations[b], Slope: conduit.slope()})
+	}
+	return i, nil
 }
 
 func getCulvertData(hsSc *bufio.Scanner, i int, lineData []string) (culverts, int, error) {
@@ -327,6 +718,10 @@ func getCulvertData(hsSc *bufio.Scanner, i int, lineData []string) (culverts, in
 			if err != nil {
 				return culvert, i, err
 			}
+			i, err = getBarrelStations(hsSc, i, &conduit)
+			if err != nil {
+				return culvert, i, err
+			}
 			culvert.Conduits = append(culvert.Conduits, conduit)
 			culvert.NumConduits++
 
@@ -344,7 +739,7 @@ func getCulvertData(hsSc *bufio.Scanner, i int, lineData []string) (culverts, in
 }
 
 func getBridgeData(hsSc *bufio.Scanner, i int, lineData []string) (bridges, int, error) {
-	bridge := bridges{}
+	bridge := bridges{Piers: []piers{}, Abutments: []abutments{}, ModelingMethod: bridgeModeling{LowFlowMethods: []string{}}}
 
 	station, err := strconv.ParseFloat(strings.TrimSpace(lineData[1]), 64)
 	if err != nil {
@@ -377,8 +772,18 @@ func getBridgeData(hsSc *bufio.Scanner, i int, lineData []string) (bridges, int,
 			}
 			bridge.DeckWidth = deckWidth
 
+			if bridge.WeirCoef, err = stringtoFloat(nextLineData[2]); err != nil {
+				return bridge, i, err
+			}
+			if len(nextLineData) > 9 {
+				if bridge.MaxSubmergence, err = stringtoFloat(nextLineData[8]); err != nil {
+					return bridge, i, err
+				}
+				bridge.IsOgee = strings.TrimSpace(nextLineData[9]) == "-1"
+			}
+
 			var upHighLowPair [2]maxMinPairs
-			upHighLowPair, i, err = getHighLowChord(hsSc, i, nextLineData[4], 80, 8)
+			bridge.UpDeck, upHighLowPair, i, err = getDeckRoadway(hsSc, i, nextLineData[4])
 			if err != nil {
 				return bridge, i, err
 			}
@@ -386,7 +791,7 @@ func getBridgeData(hsSc *bufio.Scanner, i int, lineData []string) (bridges, int,
 			bridge.UpLowChord = upHighLowPair[1]
 
 			var downHighLowPair [2]maxMinPairs
-			downHighLowPair, i, err = getHighLowChord(hsSc, i, nextLineData[5], 80, 8)
+			bridge.DownDeck, downHighLowPair, i, err = getDeckRoadway(hsSc, i, nextLineData[5])
 			if err != nil {
 				return bridge, i, err
 			}
@@ -394,8 +799,28 @@ func getBridgeData(hsSc *bufio.Scanner, i int, lineData []string) (bridges, int,
 			bridge.DownLowChord = downHighLowPair[1]
 
 		case strings.HasPrefix(line, "Pier Skew"):
+			var pier piers
+			pier, i, err = getPier(hsSc, i, strings.Split(rightofEquals(line), ","))
+			if err != nil {
+				return bridge, i, err
+			}
+			bridge.Piers = append(bridge.Piers, pier)
 			bridge.NumPiers++
 
+		case strings.HasPrefix(line, "Abutment Skew"):
+			var abutment abutments
+			abutment, i, err = getAbutment(hsSc, i, strings.Split(rightofEquals(line), ","))
+			if err != nil {
+				return bridge, i, err
+			}
+			bridge.Abutments = append(bridge.Abutments, abutment)
+
+		case strings.HasPrefix(line, "BR Coef="):
+			bridge.ModelingMethod, err = getBridgeModeling(strings.Split(rightofEquals(line), ","))
+			if err != nil {
+				return bridge, i, err
+			}
+
 		case strings.HasPrefix(line, "BC Design"):
 			return bridge, i, nil
 
@@ -409,32 +834,56 @@ func getBridgeData(hsSc *bufio.Scanner, i int, lineData []string) (bridges, int,
 	return bridge, i, nil
 }
 
-func getGates(nextLine string) (gates, error) {
-	gate := gates{}
+// getGates reads the gate record on the line after an "IW Gate Name" or "LW Gate Name" header. Its fields are the
+// name, width, height, sill elevation, discharge coefficient, trunnion, opening and head exponents, gate type, weir
+// coefficient, ogee flag, spillway height, design head and number of openings. The centerline stations of the
+// openings follow on the next lines.
+func getGates(sc *bufio.Scanner, i int, nextLine string) (gates, int, error) {
+	gate := gates{OpeningStations: []float64{}, Openings: []gateOpenings{}}
 
 	nextLineData := strings.Split(nextLine, ",")
+	field := func(n int) string {
+		if n < len(nextLineData) {
+			return strings.TrimSpace(nextLineData[n])
+		}
+		return ""
+	}
 
-	gate.Name = strings.TrimSpace(nextLineData[0])
+	gate.Name = field(0)
 
-	width, err := stringtoFloat(nextLineData[1])
-	if err != nil {
-		return gate, err
+	var err error
+	for _, v := range []struct {
+		n   int
+		val *float64
+	}{{1, &gate.Width}, {2, &gate.Height}, {3, &gate.SillElevation}, {4, &gate.DischargeCoef}, {5, &gate.TrunnionExp},
+		{6, &gate.OpeningExp}, {7, &gate.HeadExp}, {9, &gate.WeirCoef}, {11, &gate.SpillwayHeight}, {12, &gate.DesignHead}} {
+		if *v.val, err = stringtoFloat(field(v.n)); err != nil {
+			return gate, i, err
+		}
 	}
-	gate.Width = width
 
-	height, err := stringtoFloat(nextLineData[2])
-	if err != nil {
-		return gate, err
+	if field(8) != "" {
+		typeID, err := strconv.Atoi(field(8))
+		if err != nil {
+			return gate, i, err
+		}
+		gate.Type = gateTypes[typeID]
 	}
-	gate.Height = height
+	gate.IsOgee = field(10) == "-1" || field(10) == "1"
 
-	numopenings, err := strconv.Atoi(strings.TrimSpace(nextLineData[13]))
+	numopenings, err := strconv.Atoi(field(13))
 	if err != nil {
-		return gate, err
+		return gate, i, err
 	}
 	gate.NumOpenings = numopenings
 
-	return gate, nil
+	stations, i, err := fixedWidthValues(sc, i, numopenings, 80, 8)
+	if err != nil {
+		return gate, i, err
+	}
+	gate.OpeningStations = append(gate.OpeningStations, stations...)
+
+	return gate, i, nil
 }
 
 func getWeirData(rm *RasModel, fn string, i int) (weirs, error) {
@@ -495,7 +944,7 @@ func getWeirData(rm *RasModel, fn string, i int) (weirs, error) {
 
 			case strings.HasPrefix(line, "IW Gate Name"):
 				wSc.Scan()
-				gate, err := getGates(wSc.Text())
+				gate, _, err := getGates(wSc, 0, wSc.Text())
 				if err != nil {
 					return weir, err
 				}
@@ -521,11 +970,96 @@ func getWeirData(rm *RasModel, fn string, i int) (weirs, error) {
 	return weir, nil
 }
 
+func getLateralData(rm *RasModel, fn string, i int) (lateralStructures, error) {
+	lateral := lateralStructures{Gates: []gates{}, Conduits: []conduits{}}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return lateral, err
+	}
+	defer f.Close()
+
+	lSc := bufio.NewScanner(f)
+
+	li := 0
+	for lSc.Scan() {
+		li++
+		if li == i {
+			lineData := strings.Split(rightofEquals(lSc.Text()), ",")
+			station, err := strconv.ParseFloat(strings.TrimSpace(lineData[1]), 64)
+			if err != nil {
+				return lateral, err
+			}
+			lateral.Station = station
+		} else if li > i {
+			line := lSc.Text()
+			switch {
+			case strings.HasPrefix(line, "BEGIN DESCRIPTION"):
+				description, _, err := getDescription(lSc, 0, "END DESCRIPTION:")
+				if err != nil {
+					return lateral, err
+				}
+				lateral.Description += description
+
+			case strings.HasPrefix(line, "Node Name="):
+				lateral.Name = rightofEquals(line)
+
+			case strings.HasPrefix(line, "Lateral Weir WD="):
+				weirWidth, err := stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return lateral, err
+				}
+				lateral.WeirWidth = weirWidth
+
+			case strings.HasPrefix(line, "Lateral Weir SE="):
+				nElev, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+				if err != nil {
+					return lateral, err
+				}
+				nLines := numberofLines(nElev*2, 80, 8)
+
+				elev, _, err := getMaxMinElev(lSc, 0, nLines, 0, 80, 8, 2)
+				if err != nil {
+					return lateral, err
+				}
+				lateral.WeirElev = elev
+
+			case strings.HasPrefix(line, "LW Gate Name"):
+				lSc.Scan()
+				gate, _, err := getGates(lSc, 0, lSc.Text())
+				if err != nil {
+					return lateral, err
+				}
+				lateral.Gates = append(lateral.Gates, gate)
+				lateral.NumGates++
+
+			case strings.HasPrefix(line, "LW Culv="):
+				conduit, err := getConduits(line, false)
+				if err != nil {
+					return lateral, err
+				}
+				lateral.Conduits = append(lateral.Conduits, conduit)
+				lateral.NumConduits++
+
+			case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+				return lateral, nil
+
+			case strings.HasPrefix(line, "River Reach="):
+				return lateral, nil
+			}
+		}
+	}
+	return lateral, nil
+}
+
 func getHydraulicStructureData(rm *RasModel, fn string, idx int) (hydraulicStructures, error) {
 	structures := hydraulicStructures{}
 	bData := bridgeData{}
 	cData := culvertData{}
 	wData := weirData{}
+	lData := lateralStructureData{LateralStructures: []lateralStructures{}}
+	structures.PumpData = pumpStationData{PumpStations: []pumpStations{}}
+	structures.OutletData = outletData{Outlets: []outlets{}}
 
 	f, err := rm.FileStore.GetObject(fn)
 	if err != nil {
@@ -579,12 +1113,21 @@ func getHydraulicStructureData(rm *RasModel, fn string, idx int) (hydraulicStruc
 					}
 					wData.Weirs = append(wData.Weirs, weir)
 					wData.NumWeirs++
+
+				case 6:
+					lateral, err := getLateralData(rm, fn, i)
+					if err != nil {
+						return structures, err
+					}
+					lData.LateralStructures = append(lData.LateralStructures, lateral)
+					lData.NumLateralStructures++
 				}
 			}
 			if strings.HasPrefix(line, "River Reach=") {
 				structures.CulvertData = cData
 				structures.BridgeData = bData
 				structures.WeirData = wData
+				structures.LateralData = lData
 				return structures, nil
 			}
 		}
@@ -592,6 +1135,7 @@ func getHydraulicStructureData(rm *RasModel, fn string, idx int) (hydraulicStruc
 	structures.CulvertData = cData
 	structures.BridgeData = bData
 	structures.WeirData = wData
+	structures.LateralData = lData
 
 	return structures, nil
 }
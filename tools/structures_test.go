@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"bufio"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+// testModel writes files to a temporary directory and returns a model reading them from the local file system
+func testModel(t *testing.T, files map[string]string) (*RasModel, string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "mcat-ras")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &RasModel{FileStore: fs, ModelDirectory: dir}, dir
+}
+
+func TestGetGates(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   string
+		stations string
+		want     gates
+	}{
+		{
+			name:     "sluice gate with two openings",
+			record:   "Gate #1         ,10,8,100,0.6,0.16,0.72,0.62,1,2.6,0,,,2",
+			stations: "      40      60",
+			want: gates{Name: "Gate #1", Type: "Sluice", Width: 10, Height: 8, SillElevation: 100, DischargeCoef: 0.6,
+				TrunnionExp: 0.16, OpeningExp: 0.72, HeadExp: 0.62, WeirCoef: 2.6, NumOpenings: 2,
+				OpeningStations: []float64{40, 60}, Openings: []gateOpenings{}},
+		},
+		{
+			name:     "ogee overflow gate",
+			record:   "Spill,20,5,90,,,,,3,3.9,-1,12,6,1",
+			stations: "      25",
+			want: gates{Name: "Spill", Type: "Overflow (open air)", Width: 20, Height: 5, SillElevation: 90, WeirCoef: 3.9,
+				IsOgee: true, SpillwayHeight: 12, DesignHead: 6, NumOpenings: 1, OpeningStations: []float64{25},
+				Openings: []gateOpenings{}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := bufio.NewScanner(strings.NewReader(tc.stations))
+			got, _, err := getGates(sc, 0, tc.record)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetLateralData(t *testing.T) {
+	geom := `River Reach=Creek           ,Main
+Type RM Length L Ch R = 6 ,400     ,,,
+Node Name=Side
+Lateral Weir WD=15
+Lateral Weir SE= 2
+       0     105      50     106
+LW Gate Name     Wd,H,Inv,GCoef,Exp_T,Exp_O,Exp_H,Type,WCoef,Is_Ogee,SpillHt,DesHd,#Openings
+LGate           ,5,4,101,0.8,,,,1,2.6,0,,,1
+      25
+Type RM Length L Ch R = 1 ,300     ,,,
+`
+	rm, dir := testModel(t, map[string]string{"Test.g01": geom})
+
+	lateral, err := getLateralData(rm, filepath.Join(dir, "Test.g01"), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lateral.Name != "Side" || lateral.Station != 400 || lateral.WeirWidth != 15 {
+		t.Errorf("got name %q, station %v and width %v", lateral.Name, lateral.Station, lateral.WeirWidth)
+	}
+	if lateral.WeirElev != (maxMinPairs{Max: 106, Min: 105}) {
+		t.Errorf("got weir elevations %+v", lateral.WeirElev)
+	}
+	if lateral.NumGates != 1 || lateral.Gates[0].Name != "LGate" || lateral.Gates[0].SillElevation != 101 {
+		t.Errorf("got gates %+v", lateral.Gates)
+	}
+}
+
+func TestScaleStructures(t *testing.T) {
+	gate := func() gates {
+		return gates{Width: 10, Height: 8, SillElevation: 100, DischargeCoef: 0.8, WeirCoef: 3, SpillwayHeight: 12, DesignHead: 6,
+			OpeningStations: []float64{40}, Openings: []gateOpenings{{Openings: []float64{2}}}}
+	}
+	hs := hydraulicStructures{
+		WeirData: weirData{Weirs: []weirs{{WeirWidth: 20, WeirElev: maxMinPairs{110, 100}, Gates: []gates{gate()}}}},
+		LateralData: lateralStructureData{LateralStructures: []lateralStructures{{WeirWidth: 15,
+			WeirElev: maxMinPairs{106, 105}, Gates: []gates{gate()}, Conduits: []conduits{{Rise: 4, UpInvert: 100}}}}},
+	}
+	hs.scale(2)
+
+	// weir coefficients have units of length^0.5/s, discharge coefficients are dimensionless
+	want := gates{Width: 20, Height: 16, SillElevation: 200, DischargeCoef: 0.8, WeirCoef: 3 * math.Sqrt(2), SpillwayHeight: 24, DesignHead: 12,
+		OpeningStations: []float64{80}, Openings: []gateOpenings{{Openings: []float64{4}}}}
+	for _, g := range []gates{hs.WeirData.Weirs[0].Gates[0], hs.LateralData.LateralStructures[0].Gates[0]} {
+		if !reflect.DeepEqual(g, want) {
+			t.Errorf("got gate %+v, want %+v", g, want)
+		}
+	}
+
+	l := hs.LateralData.LateralStructures[0]
+	if l.WeirWidth != 30 || l.WeirElev != (maxMinPairs{212, 210}) || l.Conduits[0].Rise != 8 || l.Conduits[0].UpInvert != 200 {
+		t.Errorf("got lateral structure %+v", l)
+	}
+}
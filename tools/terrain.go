@@ -0,0 +1,327 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/USACE/filestore"
+	"github.com/dewberry/gdal"
+)
+
+// XSTerrainComparison summarizes the difference between a cross-section's station-elevation profile and the terrain
+// sampled along its cut line. Differences are the geometry elevation minus the terrain elevation.
+type XSTerrainComparison struct {
+	GeomFile             string
+	River                string
+	Reach                string
+	RiverStation         string `json:"River Station"`
+	Terrain              string
+	PointsSampled        int     `json:"Points Sampled"`
+	PointsMissing        int     `json:"Points Missing"`
+	MeanDifference       float64 `json:"Mean Difference"`
+	MeanAbsDifference    float64 `json:"Mean Absolute Difference"`
+	MaxDifference        float64 `json:"Max Difference"`
+	MaxDifferenceStation float64 `json:"Max Difference Station"`
+	Notes                string
+}
+
+// terrainRaster is a terrain raster opened from a local copy
+type terrainRaster struct {
+	local     string
+	ds        gdal.Dataset
+	band      gdal.RasterBand
+	inv       [6]float64
+	transform gdal.CoordinateTransform
+	reproject bool
+	noData    float64
+	hasNoData bool
+	zFactor   float64
+	notes     string
+}
+
+// metres per unit of the vertical units reported by gdal for a raster band
+var verticalUnits = map[string]float64{
+	"m":                  1,
+	"metre":              1,
+	"meter":              1,
+	"metres":             1,
+	"meters":             1,
+	"ft":                 internationalFoot,
+	"foot":               internationalFoot,
+	"feet":               internationalFoot,
+	"international foot": internationalFoot,
+	"us survey foot":     usSurveyFoot,
+	"us-ft":              usSurveyFoot,
+	"ftus":               usSurveyFoot,
+	"foot_us":            usSurveyFoot,
+}
+
+// terrainUnitFactor returns the factor that converts terrain elevations to the model's unit system, with a note when
+// the terrain's vertical units are assumed or not converted. Terrains without vertical units are assumed to use the
+// linear units of their coordinate reference system, as RAS Mapper does.
+func terrainUnitFactor(unitType string, crsToMeters float64, modelUnits string) (float64, string) {
+	metres, ok := verticalUnits[strings.ToLower(strings.TrimSpace(unitType))]
+	note := ""
+	switch {
+	case !ok && unitType != "":
+		return 1, fmt.Sprintf("the terrain's vertical units (%s) are not recognized, its elevations were not converted", unitType)
+	case !ok && crsToMeters <= 0:
+		return 1, "the terrain's vertical units are unknown, its elevations were not converted"
+	case !ok:
+		metres, note = crsToMeters, "the terrain's vertical units are assumed to be those of its coordinate reference system"
+	}
+
+	switch modelUnits {
+	case English:
+		return metres / usSurveyFoot, note
+	case SI:
+		return metres, note
+	}
+	return 1, "the model's unit system is unknown, the terrain's elevations were not converted"
+}
+
+// terrainFiles returns the rasters of a terrain. A RAS terrain .hdf is resolved to the GeoTIFFs stored beside it,
+// preferring those whose names begin with the terrain's name.
+func terrainFiles(fs filestore.FileStore, fn string) ([]string, error) {
+	files := []string{}
+	switch strings.ToLower(filepath.Ext(fn)) {
+	case ".tif", ".tiff":
+		return append(files, fn), nil
+
+	case ".hdf":
+		dir := filepath.Dir(fn)
+		base := strings.TrimSuffix(filepath.Base(fn), filepath.Ext(fn))
+		objects, err := fs.GetDir(dir+"/", false)
+		if err != nil {
+			return files, err
+		}
+
+		tifs, named := []string{}, []string{}
+		for _, obj := range *objects {
+			ext := strings.ToLower(filepath.Ext(obj.Name))
+			if obj.IsDir || (ext != ".tif" && ext != ".tiff") {
+				continue
+			}
+			path := filepath.Join(obj.Path, obj.Name)
+			tifs = append(tifs, path)
+			if strings.HasPrefix(obj.Name, base+".") {
+				named = append(named, path)
+			}
+		}
+		if len(named) > 0 {
+			tifs = named
+		}
+		sort.Strings(tifs)
+		if len(tifs) == 0 {
+			return files, fmt.Errorf("no GeoTIFFs were found beside the terrain %s", fn)
+		}
+		return tifs, nil
+	}
+	return files, fmt.Errorf("%s is not a supported terrain, use a GeoTIFF or a RAS terrain .hdf", fn)
+}
+
+// openTerrainRaster opens a terrain raster from the FileStore, copying it only when gdal cannot read it in place, and
+// prepares a transformation from the model's coordinate reference system to the raster's and a factor converting its
+// elevations to the model's units
+func openTerrainRaster(fs filestore.FileStore, fn string, sourceCRS string, modelUnits string) (terrainRaster, error) {
+	tr := terrainRaster{}
+
+	path := gdalPath(fs, fn)
+	if path == "" {
+		local, err := hdfLocalFile(fs, fn)
+		if err != nil {
+			return tr, err
+		}
+		tr.local, path = local, local
+	}
+
+	var err error
+	tr.ds, err = gdal.Open(path, gdal.ReadOnly)
+	if err != nil {
+		if tr.local != "" {
+			os.Remove(tr.local)
+		}
+		return tr, err
+	}
+	tr.band = tr.ds.RasterBand(1)
+	tr.noData, tr.hasNoData = tr.band.NoDataValue()
+	tr.inv = gdal.InvGeoTransform(tr.ds.GeoTransform())
+
+	crsToMeters := 0.0
+	if rasterCRS := tr.ds.Projection(); rasterCRS != "" {
+		if err := checkAxisOrder(); err != nil {
+			tr.close()
+			return tr, err
+		}
+		sourceSpRef := gdal.CreateSpatialReference(sourceCRS)
+		defer sourceSpRef.Destroy()
+		rasterSpRef := gdal.CreateSpatialReference(rasterCRS)
+		defer rasterSpRef.Destroy()
+		if !sourceSpRef.IsSame(rasterSpRef) {
+			tr.transform = gdal.CreateCoordinateTransform(sourceSpRef, rasterSpRef)
+			tr.reproject = true
+		}
+		_, crsToMeters = rasterSpRef.LinearUnits()
+	}
+	tr.zFactor, tr.notes = terrainUnitFactor(tr.band.GetUnitType(), crsToMeters, modelUnits)
+	return tr, nil
+}
+
+// sample returns the elevation of the cell containing a point given in the model's coordinates, in the model's units
+func (tr terrainRaster) sample(x, y float64) (float64, bool) {
+	if tr.reproject {
+		xs, ys, zs := []float64{x}, []float64{y}, []float64{0}
+		if !tr.transform.Transform(1, xs, ys, zs) {
+			return 0, false
+		}
+		x, y = xs[0], ys[0]
+	}
+
+	col := int(math.Floor(tr.inv[0] + x*tr.inv[1] + y*tr.inv[2]))
+	row := int(math.Floor(tr.inv[3] + x*tr.inv[4] + y*tr.inv[5]))
+	if col < 0 || row < 0 || col >= tr.ds.RasterXSize() || row >= tr.ds.RasterYSize() {
+		return 0, false
+	}
+
+	buffer := make([]float64, 1)
+	if err := tr.band.IO(gdal.Read, col, row, 1, 1, buffer, 1, 1, 0, 0); err != nil {
+		return 0, false
+	}
+	if tr.hasNoData && buffer[0] == tr.noData {
+		return 0, false
+	}
+	return buffer[0] * tr.zFactor, true
+}
+
+func (tr terrainRaster) close() {
+	if tr.reproject {
+		tr.transform.Destroy()
+	}
+	tr.ds.Close()
+	if tr.local != "" {
+		os.Remove(tr.local)
+	}
+}
+
+// summarizeDifferences records the mean, mean absolute and largest differences between the geometry and terrain
+// elevations, where a missing terrain elevation is nil
+func summarizeDifferences(c *XSTerrainComparison, stations []float64, geometry []float64, terrain []interface{}) {
+	sum, sumAbs := 0.0, 0.0
+	for i, t := range terrain {
+		elev, ok := t.(float64)
+		if !ok {
+			c.PointsMissing++
+			continue
+		}
+		d := geometry[i] - elev
+		if c.PointsSampled == 0 || math.Abs(d) > math.Abs(c.MaxDifference) {
+			c.MaxDifference = d
+			c.MaxDifferenceStation = stations[i]
+		}
+		sum += d
+		sumAbs += math.Abs(d)
+		c.PointsSampled++
+	}
+	if c.PointsSampled > 0 {
+		c.MeanDifference = sum / float64(c.PointsSampled)
+		c.MeanAbsDifference = sumAbs / float64(c.PointsSampled)
+	}
+}
+
+// TerrainComparison samples a terrain at every point of each cross-section's station-elevation profile along its
+// cut line and summarizes the differences per cross-section. The terrain is a FileStore key to a GeoTIFF or RAS
+// terrain .hdf, or the first terrain in the RAS Mapper file when empty. Elevations are compared in the model's units,
+// converted from the terrain's vertical units, and assumptions about those units are added to the notes.
+func (rm *RasModel) TerrainComparison(terrain string) ([]XSTerrainComparison, error) {
+	comparisons := []XSTerrainComparison{}
+
+	if rm.Metadata.Projection == "" {
+		return comparisons, errors.New("no valid coordinate reference system")
+	}
+
+	if terrain == "" {
+		for _, layer := range rm.Metadata.RasMapFile.Terrains {
+			if layer.Exists {
+				terrain = layer.Filename
+				break
+			}
+		}
+		if terrain == "" {
+			return comparisons, errors.New("no terrain was given and none was found in the RAS Mapper file")
+		}
+	}
+
+	files, err := terrainFiles(rm.FileStore, terrain)
+	if err != nil {
+		return comparisons, err
+	}
+
+	rasters := []terrainRaster{}
+	defer func() {
+		for _, tr := range rasters {
+			tr.close()
+		}
+	}()
+	unitNotes, seen := []string{}, map[string]bool{}
+	for _, fn := range files {
+		tr, err := openTerrainRaster(rm.FileStore, fn, rm.Metadata.Projection, rm.Metadata.ProjFileContents.Units)
+		if err != nil {
+			return comparisons, err
+		}
+		rasters = append(rasters, tr)
+		if tr.notes != "" && !seen[tr.notes] {
+			unitNotes, seen[tr.notes] = append(unitNotes, tr.notes), true
+		}
+	}
+
+	for _, g := range rm.Metadata.GeomFiles {
+		reaches, err := readXSGeometry(rm.FileStore, g.Path)
+		if err != nil {
+			return comparisons, err
+		}
+		for _, reach := range reaches {
+			for _, xs := range reach.XS {
+				c := XSTerrainComparison{
+					GeomFile:     filepath.Base(g.Path),
+					River:        reach.River,
+					Reach:        reach.Reach,
+					RiverStation: xs.Name,
+					Terrain:      terrain,
+				}
+				if !xs.hasGIS() {
+					c.Notes = "the cross-section does not have a cut line and station-elevation profile"
+					comparisons = append(comparisons, c)
+					continue
+				}
+
+				stations, geometry, sampled := []float64{}, []float64{}, []interface{}{}
+				for _, pair := range xs.Profile {
+					xy := xs.stationXY(pair[0])
+					var elev interface{}
+					for _, tr := range rasters {
+						if z, ok := tr.sample(xy[0], xy[1]); ok {
+							elev = z
+							break
+						}
+					}
+					stations = append(stations, pair[0])
+					geometry = append(geometry, pair[1])
+					sampled = append(sampled, elev)
+				}
+				summarizeDifferences(&c, stations, geometry, sampled)
+				notes := unitNotes
+				if c.PointsSampled == 0 {
+					notes = append([]string{"the cross-section does not overlap the terrain"}, unitNotes...)
+				}
+				c.Notes = strings.Join(notes, "; ")
+				comparisons = append(comparisons, c)
+			}
+		}
+	}
+	return comparisons, nil
+}
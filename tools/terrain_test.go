@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTerrainUnitFactor(t *testing.T) {
+	tests := []struct {
+		name        string
+		unitType    string
+		crsToMeters float64
+		modelUnits  string
+		want        float64
+		noted       bool
+	}{
+		{"metres to SI", "metre", 1, SI, 1, false},
+		{"metres to English", "m", 1, English, 1 / usSurveyFoot, false},
+		{"US survey feet to English", "US survey foot", usSurveyFoot, English, 1, false},
+		{"international feet to SI", "ft", internationalFoot, SI, internationalFoot, false},
+		{"no units, CRS in US survey feet", "", usSurveyFoot, SI, usSurveyFoot, true},
+		{"no units or CRS", "", 0, SI, 1, true},
+		{"unrecognized units", "cubits", 1, SI, 1, true},
+		{"unknown model units", "m", 1, "", 1, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, note := terrainUnitFactor(tc.unitType, tc.crsToMeters, tc.modelUnits)
+			if math.Abs(got-tc.want) > 1e-12 || (note != "") != tc.noted {
+				t.Errorf("got (%v, %q), want %v with a note %v", got, note, tc.want, tc.noted)
+			}
+		})
+	}
+}
+
+func TestGDALPath(t *testing.T) {
+	rm, dir := testModel(t, map[string]string{})
+	fn := filepath.Join(dir, "Terrain.tif")
+	if got := gdalPath(rm.FileStore, fn); got != fn {
+		t.Errorf("got %q, want the local file %q", got, fn)
+	}
+}
+
+func TestTerrainFiles(t *testing.T) {
+	rm, dir := testModel(t, map[string]string{
+		"Terrain.hdf":         "",
+		"Terrain.Bare.tif":    "",
+		"Terrain.Channel.tif": "",
+		"Other.tif":           "",
+		"Terrain.vrt":         "",
+	})
+
+	tests := []struct {
+		name string
+		fn   string
+		want []string
+	}{
+		{"GeoTIFF", filepath.Join(dir, "Other.tif"), []string{filepath.Join(dir, "Other.tif")}},
+		{"RAS terrain", filepath.Join(dir, "Terrain.hdf"),
+			[]string{filepath.Join(dir, "Terrain.Bare.tif"), filepath.Join(dir, "Terrain.Channel.tif")}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := terrainFiles(rm.FileStore, tc.fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := terrainFiles(rm.FileStore, filepath.Join(dir, "Terrain.vrt")); err == nil {
+		t.Error("got no error for an unsupported terrain")
+	}
+}
+
+func TestSummarizeDifferences(t *testing.T) {
+	c := XSTerrainComparison{}
+	summarizeDifferences(&c, []float64{0, 10, 20, 30}, []float64{100, 95, 96, 101}, []interface{}{99.0, 96.0, nil, 103.0})
+
+	want := XSTerrainComparison{PointsSampled: 3, PointsMissing: 1, MeanDifference: -2.0 / 3, MeanAbsDifference: 4.0 / 3,
+		MaxDifference: -2, MaxDifferenceStation: 30}
+	if math.Abs(c.MeanDifference-want.MeanDifference) > 1e-12 || math.Abs(c.MeanAbsDifference-want.MeanAbsDifference) > 1e-12 {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+	c.MeanDifference, c.MeanAbsDifference = want.MeanDifference, want.MeanAbsDifference
+	if c != want {
+		t.Errorf("got %+v, want %+v", c, want)
+	}
+}
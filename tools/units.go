@@ -0,0 +1,295 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/dewberry/gdal"
+)
+
+// Unit systems of a RAS project
+const (
+	English string = "English"
+	SI      string = "SI"
+)
+
+// metres per foot of the US survey foot used by HEC-RAS English units and of the international foot
+const (
+	usSurveyFoot      float64 = 1200.0 / 3937.0
+	internationalFoot float64 = 0.3048
+)
+
+// parseUnitSystem returns the unit system named on a project file line such as "English Units"
+func parseUnitSystem(s string) string {
+	switch strings.TrimSpace(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "units")) {
+	case "english":
+		return English
+	case "si", "metric":
+		return SI
+	}
+	return ""
+}
+
+// unitFactor returns the factor that converts lengths from one unit system to another
+func unitFactor(from, to string) (float64, error) {
+	if to == "" || from == to {
+		return 1, nil
+	}
+	if from == "" {
+		return 1, errors.New("unable to convert units, the unit system of the model is unknown")
+	}
+	switch {
+	case from == English && to == SI:
+		return usSurveyFoot, nil
+	case from == SI && to == English:
+		return 1 / usSurveyFoot, nil
+	}
+	return 1, fmt.Errorf("unable to convert units from %s to %s", from, to)
+}
+
+// square feet per acre, the unit of English storage area areas, which are given with volumes in acre-feet. SI storage
+// areas are given in 1000 m2 and 1000 m3.
+const (
+	squareFeetPerAcre float64 = 43560
+	siStorageUnit     float64 = 1000
+)
+
+// storageFactors returns the factors that convert storage area areas and volumes between acres and acre-feet and
+// 1000 m2 and 1000 m3 from one unit system to another
+func storageFactors(from, to string) (float64, float64) {
+	switch {
+	case from == English && to == SI:
+		return squareFeetPerAcre * usSurveyFoot * usSurveyFoot / siStorageUnit,
+			squareFeetPerAcre * usSurveyFoot * usSurveyFoot * usSurveyFoot / siStorageUnit
+	case from == SI && to == English:
+		return siStorageUnit / (squareFeetPerAcre * usSurveyFoot * usSurveyFoot),
+			siStorageUnit / (squareFeetPerAcre * usSurveyFoot * usSurveyFoot * usSurveyFoot)
+	}
+	return 1, 1
+}
+
+// errInternationalFoot is reported for an English model in a coordinate reference system using the international
+// foot. The feet differ by 2 parts per million, so the mismatch is always a warning.
+var errInternationalFoot = errors.New("The coordinate reference system uses the international foot while the model uses US survey feet")
+
+// checkUnitConsistency checks that the unit system used by the model and its coordinate reference system are the same
+func checkUnitConsistency(modelUnits string, sourceCRS string) error {
+	sourceSpRef := gdal.CreateSpatialReference(sourceCRS)
+	defer sourceSpRef.Destroy()
+
+	crsUnits, toMeters := sourceSpRef.LinearUnits()
+	if toMeters <= 0 {
+		return errors.New("Unable to check unit consistency, could not identify the coordinate reference system's units")
+	}
+
+	switch {
+	case modelUnits == "":
+		return errors.New("Unable to check unit consistency, could not identify the model's unit system")
+
+	case modelUnits == English && math.Abs(toMeters-internationalFoot) < 1e-9:
+		return errInternationalFoot
+
+	case modelUnits == English && math.Abs(toMeters-usSurveyFoot) < 1e-9:
+		return nil
+
+	case modelUnits == SI && toMeters == 1:
+		return nil
+	}
+	return fmt.Errorf("The unit system of the model (%s) and coordinate reference system (%s) are inconsistent", modelUnits, crsUnits)
+}
+
+func (mm *maxMinPairs) scale(f float64) {
+	mm.Max *= f
+	mm.Min *= f
+}
+
+func (c *conduits) scale(f float64) {
+	c.Rise *= f
+	c.Span *= f
+	c.Length *= f
+	c.UpInvert *= f
+	c.DownInvert *= f
+	c.DepthBlocked *= f
+	for i := range c.Barrels {
+		c.Barrels[i].UpStation *= f
+		c.Barrels[i].DownStation *= f
+	}
+}
+
+// scale converts a gate's dimensions, elevations, opening stations and openings and its weir coefficient, which has
+// units of length^0.5/s. The discharge coefficient and exponents are dimensionless.
+func (g *gates) scale(f float64) {
+	g.WeirCoef *= math.Sqrt(f)
+	g.Width *= f
+	g.Height *= f
+	g.SillElevation *= f
+	g.SpillwayHeight *= f
+	g.DesignHead *= f
+	for i := range g.OpeningStations {
+		g.OpeningStations[i] *= f
+	}
+	for _, o := range g.Openings {
+		for i := range o.Openings {
+			o.Openings[i] *= f
+		}
+	}
+}
+
+// scale converts the elevations, widths and lengths of a reach's structures and their weir coefficients. River
+// stations are names and are not converted, and the dimensionless loss, discharge and pier coefficients keep their
+// values.
+func (hs *hydraulicStructures) scale(f float64) {
+	for i := range hs.CulvertData.Culverts {
+		c := &hs.CulvertData.Culverts[i]
+		c.DeckWidth *= f
+		for _, mm := range []*maxMinPairs{&c.UpHighChord, &c.UpLowChord, &c.DownHighChord, &c.DownLowChord} {
+			mm.scale(f)
+		}
+		for j := range c.Conduits {
+			c.Conduits[j].scale(f)
+		}
+	}
+	for i := range hs.BridgeData.Bridges {
+		b := &hs.BridgeData.Bridges[i]
+		b.DeckWidth *= f
+		for _, mm := range []*maxMinPairs{&b.UpHighChord, &b.UpLowChord, &b.DownHighChord, &b.DownLowChord} {
+			mm.scale(f)
+		}
+		for _, deck := range [][]deckPoint{b.UpDeck, b.DownDeck} {
+			for j := range deck {
+				deck[j].Station *= f
+				deck[j].HighChord *= f
+				deck[j].LowChord *= f
+			}
+		}
+		for j := range b.Piers {
+			p := &b.Piers[j]
+			p.UpStation *= f
+			p.DownStation *= f
+			for _, widths := range [][]widthElevation{p.UpWidths, p.DownWidths} {
+				for k := range widths {
+					widths[k].Width *= f
+					widths[k].Elevation *= f
+				}
+			}
+		}
+		for _, a := range b.Abutments {
+			for _, se := range [][][2]float64{a.UpSE, a.DownSE} {
+				for k := range se {
+					se[k][0] *= f
+					se[k][1] *= f
+				}
+			}
+		}
+		b.ModelingMethod.MaxLowChord *= f
+		b.WeirCoef *= math.Sqrt(f)
+	}
+	for i := range hs.WeirData.Weirs {
+		w := &hs.WeirData.Weirs[i]
+		w.WeirWidth *= f
+		w.WeirElev.scale(f)
+		for j := range w.Gates {
+			w.Gates[j].scale(f)
+		}
+		for j := range w.Conduits {
+			w.Conduits[j].scale(f)
+		}
+	}
+	for i := range hs.LateralData.LateralStructures {
+		l := &hs.LateralData.LateralStructures[i]
+		l.WeirWidth *= f
+		l.WeirElev.scale(f)
+		for j := range l.Gates {
+			l.Gates[j].scale(f)
+		}
+		for j := range l.Conduits {
+			l.Conduits[j].scale(f)
+		}
+	}
+	for _, p := range hs.PumpData.PumpStations {
+		for _, g := range p.Groups {
+			for j := range g.Pumps {
+				g.Pumps[j].OnElevation *= f
+				g.Pumps[j].OffElevation *= f
+			}
+			scaleCurve(g.Curve, f, f*f*f)
+		}
+	}
+	for _, o := range hs.OutletData.Outlets {
+		scaleCurve(o.RatingCurve, f, f*f*f)
+	}
+}
+
+// scaleCurve converts the head or elevation and the flow of each pair of a pump curve or rating curve
+func scaleCurve(curve [][2]float64, lengthFactor float64, flowFactor float64) {
+	for i := range curve {
+		curve[i][0] *= lengthFactor
+		curve[i][1] *= flowFactor
+	}
+}
+
+// scale converts the stations, elevations and levee positions of a cross-section profile
+func (p *XSProfile) scale(f float64) {
+	for i := range p.Stations {
+		p.Stations[i] *= f
+		p.Elevations[i] *= f
+	}
+	for i := range p.ManningsN {
+		p.ManningsN[i].Station *= f
+	}
+	for i := range p.BankStations {
+		p.BankStations[i] *= f
+	}
+	for i := range p.IneffectiveAreas {
+		a := &p.IneffectiveAreas[i]
+		a.StartStation *= f
+		a.EndStation *= f
+		a.Elevation *= f
+	}
+	for i := range p.Levees {
+		p.Levees[i].Station *= f
+		p.Levees[i].Elevation *= f
+	}
+}
+
+// lengthFactor is the factor that converts the model's lengths to its output units
+func (rm *RasModel) lengthFactor() (float64, error) {
+	return unitFactor(rm.Metadata.ProjFileContents.Units, rm.OutputUnits)
+}
+
+// units returns the unit system of values returned for the model
+func (rm *RasModel) units() string {
+	if rm.OutputUnits != "" {
+		return rm.OutputUnits
+	}
+	return rm.Metadata.ProjFileContents.Units
+}
+
+// ConvertUnits sets the unit system, English or SI, of the elevations, stations, widths and lengths returned for the
+// model and converts its hydraulic structures, including weir coefficients. An empty unit system keeps the model's
+// units.
+func (rm *RasModel) ConvertUnits(outputUnits string) error {
+	units := parseUnitSystem(outputUnits)
+	if outputUnits != "" && units == "" {
+		return fmt.Errorf("%s is not a valid unit system, use English or SI", outputUnits)
+	}
+
+	f, err := unitFactor(rm.Metadata.ProjFileContents.Units, units)
+	if err != nil {
+		return err
+	}
+	prevFactor, err := rm.lengthFactor()
+	if err != nil {
+		return err
+	}
+
+	for i := range rm.Metadata.GeomFiles {
+		for j := range rm.Metadata.GeomFiles[i].Structures {
+			rm.Metadata.GeomFiles[i].Structures[j].scale(f / prevFactor)
+		}
+	}
+	rm.OutputUnits = units
+	return nil
+}
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseUnitSystem(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"English Units", English},
+		{" english units ", English},
+		{"SI Units", SI},
+		{"Metric", SI},
+		{"si", SI},
+		{"Imperial Units", ""},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		if got := parseUnitSystem(tc.line); got != tc.want {
+			t.Errorf("parseUnitSystem(%q) = %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
+
+func TestUnitFactor(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     float64
+		wantErr  bool
+	}{
+		{English, "", 1, false},
+		{English, English, 1, false},
+		{English, SI, 0.3048006096, false},
+		{SI, English, 3.2808333333, false},
+		{"", SI, 1, true},
+		{English, "Imperial", 1, true},
+	}
+	for _, tc := range tests {
+		got, err := unitFactor(tc.from, tc.to)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("unitFactor(%q, %q) returned error %v", tc.from, tc.to, err)
+		}
+		if math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("unitFactor(%q, %q) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestStorageFactors(t *testing.T) {
+	tests := []struct {
+		name       string
+		from, to   string
+		wantArea   float64
+		wantVolume float64
+	}{
+		{"model units", English, English, 1, 1},
+		{"acres to 1000 m2", English, SI, 4.0468726, 1.2334892},
+		{"1000 m2 to acres", SI, English, 0.2471044, 0.8107083},
+		{"no output units", SI, "", 1, 1},
+		{"unknown model units", "", SI, 1, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			area, volume := storageFactors(tc.from, tc.to)
+			if math.Abs(area-tc.wantArea) > 1e-6 || math.Abs(volume-tc.wantVolume) > 1e-6 {
+				t.Errorf("got %v and %v, want %v and %v", area, volume, tc.wantArea, tc.wantVolume)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package tools
 import (
 	"bufio"
 	"errors"
+	"path/filepath"
 	"strings"
+
+	"github.com/USACE/filestore"
 )
 
 func maxValue(values []float64) (float64, error) {
@@ -59,3 +62,17 @@ func getDescription(sc *bufio.Scanner, idx int, endLine string) (string, int, er
 	}
 	return description, idx, nil
 }
+
+// fileExists checks that a file is listed in its directory of the FileStore, without opening it
+func fileExists(fs filestore.FileStore, fn string) bool {
+	objects, err := fs.GetDir(filepath.Dir(fn)+"/", false)
+	if err != nil {
+		return false
+	}
+	for _, obj := range *objects {
+		if !obj.IsDir && obj.Name == filepath.Base(fn) {
+			return true
+		}
+	}
+	return false
+}
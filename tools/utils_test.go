@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileExists(t *testing.T) {
+	rm, dir := testModel(t, map[string]string{"Test.prj": "Proj Title=Test\n"})
+	if err := os.Mkdir(filepath.Join(dir, "Terrain"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		fn   string
+		want bool
+	}{
+		{filepath.Join(dir, "Test.prj"), true},
+		{filepath.Join(dir, "Test.g01"), false},
+		{filepath.Join(dir, "Terrain"), false},
+		{filepath.Join(dir, "Missing", "Terrain.hdf"), false},
+	}
+	for _, tc := range tests {
+		if got := fileExists(rm.FileStore, tc.fn); got != tc.want {
+			t.Errorf("fileExists(%s) = %v, want %v", tc.fn, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/USACE/filestore"
+)
+
+// VersionReport describes the HEC-RAS version of each model file and the version derived for the model
+type VersionReport struct {
+	ModelVersion string
+	Mixed        bool
+	Versions     []string
+	Files        []FileVersion
+}
+
+// FileVersion is the HEC-RAS version of a single model file
+type FileVersion struct {
+	File           string
+	FileType       string
+	ProgramVersion string `json:"Program Version"`
+	Major          int
+	Minor          int
+	Patch          int
+	Source         string
+}
+
+type rasVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+type versionKeyword struct {
+	Keyword    string
+	MinVersion rasVersion
+}
+
+// versionKeywords are keywords introduced in a given HEC-RAS release, used to guess the minimum version
+// of files that do not record a "Program Version="
+var versionKeywords map[string][]versionKeyword = map[string][]versionKeyword{
+	"Geometry": {
+		{"Pump Station Name=", rasVersion{4, 0, 0}},
+		{"Storage Area Is2D=", rasVersion{5, 0, 0}},
+		{"Storage Area Point Generation Data=", rasVersion{5, 0, 0}},
+		{"BreakLine Name=", rasVersion{5, 0, 0}},
+		{"LCMann Time=", rasVersion{5, 0, 0}},
+	},
+	"Plan": {
+		{"Run RASMapper=", rasVersion{5, 0, 0}},
+		{"UNET D2 ", rasVersion{5, 0, 0}},
+		{"UNET D1 Cores=", rasVersion{5, 0, 0}},
+	},
+	"Flow": {
+		{"Met BC=", rasVersion{6, 0, 0}},
+		{"Precipitation Mode=", rasVersion{6, 0, 0}},
+	},
+}
+
+var versionRE *regexp.Regexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*`)
+
+func (v rasVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func (v rasVersion) less(o rasVersion) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// parseVersion converts a "Program Version=" value into major, minor and patch numbers.
+// HEC-RAS writes most versions as "5.07" or "4.10", which are read as 5.0.7 and 4.1.0
+func parseVersion(s string) (rasVersion, error) {
+	v := rasVersion{}
+	num := versionRE.FindString(strings.TrimSpace(s))
+	if num == "" {
+		return v, fmt.Errorf("could not parse the program version %q", s)
+	}
+
+	parts := strings.Split(num, ".")
+	values := []int{}
+	if len(parts) == 2 && len(parts[1]) == 2 {
+		parts = []string{parts[0], parts[1][:1], parts[1][1:]}
+	}
+	for _, p := range parts {
+		val, err := strconv.Atoi(p)
+		if err != nil {
+			return v, err
+		}
+		values = append(values, val)
+	}
+
+	v.Major = values[0]
+	if len(values) > 1 {
+		v.Minor = values[1]
+	}
+	if len(values) > 2 {
+		v.Patch = values[2]
+	}
+	return v, nil
+}
+
+// guessVersion scans a file for keywords that were introduced in a given HEC-RAS version
+func guessVersion(fs filestore.FileStore, fn string, fileType string) (rasVersion, bool, error) {
+	guess := rasVersion{}
+	found := false
+
+	keywords := versionKeywords[fileType]
+	if len(keywords) == 0 {
+		return guess, found, nil
+	}
+
+	f, err := fs.GetObject(fn)
+	if err != nil {
+		return guess, found, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		for _, kw := range keywords {
+			if strings.HasPrefix(line, kw.Keyword) && guess.less(kw.MinVersion) {
+				guess = kw.MinVersion
+				found = true
+			}
+		}
+	}
+	return guess, found, nil
+}
+
+func newFileVersion(fs filestore.FileStore, fn string, fileType string, programVersion string) FileVersion {
+	fv := FileVersion{File: filepath.Base(fn), FileType: fileType, ProgramVersion: programVersion}
+
+	if programVersion != "" {
+		v, err := parseVersion(programVersion)
+		if err == nil {
+			fv.Major, fv.Minor, fv.Patch = v.Major, v.Minor, v.Patch
+			fv.Source = "Program Version"
+			return fv
+		}
+		fmt.Println(err)
+	}
+
+	v, found, err := guessVersion(fs, fn, fileType)
+	if err != nil {
+		fmt.Println(err)
+	}
+	if found {
+		fv.Major, fv.Minor, fv.Patch = v.Major, v.Minor, v.Patch
+		fv.Source = "Heuristic"
+		return fv
+	}
+
+	fv.Source = "Unknown"
+	return fv
+}
+
+// fileVersions lists the "Program Version=" of each plan, geometry and flow file by extension, such as
+// ".p01: 5.07, .g01: 5.07", the model version reported before the structured version report
+func fileVersions(rm *RasModel) string {
+	versions := []string{}
+	for _, p := range rm.Metadata.PlanFiles {
+		if p.ProgramVersion != "" {
+			versions = append(versions, fmt.Sprintf("%s: %s", p.FileExt, p.ProgramVersion))
+		}
+	}
+	for _, g := range rm.Metadata.GeomFiles {
+		if g.ProgramVersion != "" {
+			versions = append(versions, fmt.Sprintf("%s: %s", g.FileExt, g.ProgramVersion))
+		}
+	}
+	for _, f := range rm.Metadata.FlowFiles {
+		if f.ProgramVersion != "" {
+			versions = append(versions, fmt.Sprintf("%s: %s", f.FileExt, f.ProgramVersion))
+		}
+	}
+	return strings.Join(versions, ", ")
+}
+
+// getVersionReport collects the version of every plan, geometry and flow file and derives
+// the model version as the most recent version recorded in the files
+func getVersionReport(rm *RasModel) VersionReport {
+	report := VersionReport{Versions: make([]string, 0), Files: make([]FileVersion, 0)}
+
+	for _, p := range rm.Metadata.PlanFiles {
+		report.Files = append(report.Files, newFileVersion(rm.FileStore, p.Path, "Plan", p.ProgramVersion))
+	}
+	for _, g := range rm.Metadata.GeomFiles {
+		report.Files = append(report.Files, newFileVersion(rm.FileStore, g.Path, "Geometry", g.ProgramVersion))
+	}
+	for _, f := range rm.Metadata.FlowFiles {
+		report.Files = append(report.Files, newFileVersion(rm.FileStore, f.Path, "Flow", f.ProgramVersion))
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].File < report.Files[j].File
+	})
+
+	var latest, latestGuess rasVersion
+	recorded, guessed := false, false
+	releases := map[string]bool{}
+	for _, fv := range report.Files {
+		v := rasVersion{fv.Major, fv.Minor, fv.Patch}
+		switch fv.Source {
+		case "Program Version":
+			if !recorded || latest.less(v) {
+				latest = v
+			}
+			recorded = true
+			release := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+			if !releases[release] {
+				releases[release] = true
+				report.Versions = append(report.Versions, release)
+			}
+
+		case "Heuristic":
+			if !guessed || latestGuess.less(v) {
+				latestGuess = v
+			}
+			guessed = true
+		}
+	}
+	sort.Strings(report.Versions)
+
+	switch {
+	case recorded:
+		report.ModelVersion = latest.String()
+	case guessed:
+		report.ModelVersion = fmt.Sprintf(">=%s", latestGuess.String())
+	}
+	report.Mixed = len(report.Versions) > 1
+
+	return report
+}
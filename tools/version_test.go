@@ -0,0 +1,40 @@
+package tools
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    rasVersion
+		wantErr bool
+	}{
+		{"5.07", rasVersion{5, 0, 7}, false},
+		{"4.10", rasVersion{4, 1, 0}, false},
+		{"6.3.1", rasVersion{6, 3, 1}, false},
+		{" 6.00 ", rasVersion{6, 0, 0}, false},
+		{"3.1", rasVersion{3, 1, 0}, false},
+		{"unknown", rasVersion{}, true},
+	}
+	for _, tc := range tests {
+		got, err := parseVersion(tc.s)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("parseVersion(%q) = %v, %v, want %v", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestFileVersions(t *testing.T) {
+	rm := &RasModel{}
+	rm.Metadata.PlanFiles = []PlanFileContents{{FileExt: ".p01", ProgramVersion: "5.07"}}
+	rm.Metadata.GeomFiles = []GeomFileContents{{FileExt: ".g01", ProgramVersion: "5.07"}, {FileExt: ".g02"}}
+	rm.Metadata.FlowFiles = []FlowFileContents{{FileExt: ".f01", ProgramVersion: "4.10"}}
+
+	if got, want := fileVersions(rm), ".p01: 5.07, .g01: 5.07, .f01: 4.10"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	report := getVersionReport(&RasModel{Metadata: ProjectMetadata{PlanFiles: rm.Metadata.PlanFiles, FlowFiles: rm.Metadata.FlowFiles}})
+	if report.ModelVersion != "5.0.7" || !report.Mixed {
+		t.Errorf("got model version %s, mixed %v, want 5.0.7, mixed true", report.ModelVersion, report.Mixed)
+	}
+}
@@ -0,0 +1,304 @@
+package tools
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+
+	"github.com/USACE/filestore"
+)
+
+// reachGeometry holds a reach's centerline and cross-sections in the model's coordinates and units
+type reachGeometry struct {
+	River      string
+	Reach      string
+	Centerline [][2]float64
+	XS         []xsGeometry
+}
+
+// xsGeometry holds the data of a single cross-section block
+type xsGeometry struct {
+	Name         string
+	Station      float64
+	Lengths      [3]float64
+	CutLine      [][2]float64
+	Profile      [][2]float64
+	Mannings     [][3]float64
+	BankStations []float64
+	Ineffective  [][3]float64
+	Permanent    []bool
+	Levees       [2][]float64
+}
+
+// storageAreaGeometry holds a storage area or 2D flow area perimeter in the model's coordinates and units
+type storageAreaGeometry struct {
+	Name      string
+	Is2D      bool
+	Perimeter [][2]float64
+}
+
+// name returns the reach name formatted the same way as the river centerline features
+func (rg reachGeometry) name() string {
+	return rg.River + ", " + rg.Reach
+}
+
+// stationScale is the ratio of the cut line length to the station-elevation profile length
+func (xs xsGeometry) stationScale() float64 {
+	if len(xs.Profile) < 2 {
+		return 1
+	}
+	lenProfile := xs.Profile[len(xs.Profile)-1][0] - xs.Profile[0][0]
+	if lenProfile <= 0 {
+		return 1
+	}
+	return polylineLength(xs.CutLine) / lenProfile
+}
+
+// stationXY locates a cross-section station on the cut line, scaling stations proportionally
+// when the cut line and profile lengths differ
+func (xs xsGeometry) stationXY(station float64) [2]float64 {
+	return interpXY(xs.CutLine, (station-xs.Profile[0][0])*xs.stationScale())
+}
+
+// hasGIS is true when the cross-section has the cut line and profile needed to place it in space
+func (xs xsGeometry) hasGIS() bool {
+	return len(xs.CutLine) >= 2 && len(xs.Profile) >= 2
+}
+
+// readXSGeometry reads the river centerlines and cross-sections of a geometry file
+func readXSGeometry(fs filestore.FileStore, fn string) ([]reachGeometry, error) {
+	reaches := []reachGeometry{}
+
+	f, err := fs.GetObject(fn)
+	if err != nil {
+		return reaches, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+
+	var reach *reachGeometry
+	var xs *xsGeometry
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "River Reach="):
+			riverReach := strings.Split(rightofEquals(line), ",")
+			reaches = append(reaches, reachGeometry{River: strings.TrimSpace(riverReach[0]), Reach: strings.TrimSpace(riverReach[1])})
+			reach = &reaches[len(reaches)-1]
+			xs = nil
+
+		case strings.HasPrefix(line, "Storage Area="):
+			reach = nil
+			xs = nil
+
+		case reach != nil && strings.HasPrefix(line, "Reach XY="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return reaches, err
+			}
+			if n > 0 {
+				reach.Centerline, err = dataPairsfromTextBlock(sc, n, 64, 16)
+				if err != nil {
+					return reaches, err
+				}
+			}
+
+		case reach != nil && strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			xs = nil
+			data := strings.Split(rightofEquals(line), ",")
+			if strings.TrimSpace(data[0]) != "1" {
+				continue
+			}
+			newXS, err := xsHeader(data)
+			if err != nil {
+				return reaches, err
+			}
+			reach.XS = append(reach.XS, newXS)
+			xs = &reach.XS[len(reach.XS)-1]
+
+		case xs != nil && strings.HasPrefix(line, "XS GIS Cut Line="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return reaches, err
+			}
+			if n > 0 {
+				xs.CutLine, err = dataPairsfromTextBlock(sc, n, 64, 16)
+				if err != nil {
+					return reaches, err
+				}
+			}
+
+		case xs != nil && strings.HasPrefix(line, "#Sta/Elev="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return reaches, err
+			}
+			if n > 0 {
+				xs.Profile, err = dataPairsfromTextBlock(sc, n, 80, 8)
+				if err != nil {
+					return reaches, err
+				}
+			}
+
+		case xs != nil && strings.HasPrefix(line, "#Mann="):
+			n, err := strconv.Atoi(strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0]))
+			if err != nil {
+				return reaches, err
+			}
+			xs.Mannings, err = tripletsfromTextBlock(sc, n, 72, 8)
+			if err != nil {
+				return reaches, err
+			}
+
+		case xs != nil && strings.HasPrefix(line, "#XS Ineff="):
+			n, err := strconv.Atoi(strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0]))
+			if err != nil {
+				return reaches, err
+			}
+			xs.Ineffective, err = tripletsfromTextBlock(sc, n, 72, 8)
+			if err != nil {
+				return reaches, err
+			}
+
+		case xs != nil && strings.HasPrefix(line, "Permanent Ineff="):
+			sc.Scan()
+			for _, flag := range strings.Fields(sc.Text()) {
+				xs.Permanent = append(xs.Permanent, flag == "T")
+			}
+
+		case xs != nil && strings.HasPrefix(line, "Levee="):
+			xs.Levees, err = levees(rightofEquals(line))
+			if err != nil {
+				return reaches, err
+			}
+
+		case xs != nil && strings.HasPrefix(line, "Bank Sta="):
+			for _, s := range strings.Split(rightofEquals(line), ",") {
+				bankStation, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+				if err != nil {
+					return reaches, err
+				}
+				xs.BankStations = append(xs.BankStations, bankStation)
+			}
+		}
+	}
+	return reaches, nil
+}
+
+// tripletsfromTextBlock reads n groups of three fixed width values, such as Manning's n breaks
+// (station, n value, 0) or ineffective flow areas (start station, end station, elevation)
+func tripletsfromTextBlock(sc *bufio.Scanner, n int, colWidth int, valueWidth int) ([][3]float64, error) {
+	triplets := [][3]float64{}
+	values := []float64{}
+	for len(values) < n*3 && sc.Scan() {
+		line := sc.Text()
+		for s := 0; s < colWidth && s < len(line); s += valueWidth {
+			end := s + valueWidth
+			if end > len(line) {
+				end = len(line)
+			}
+			val, err := stringtoFloat(line[s:end])
+			if err != nil {
+				return triplets, err
+			}
+			values = append(values, val)
+			if len(values) == n*3 {
+				break
+			}
+		}
+	}
+	for i := 0; i+2 < len(values); i += 3 {
+		triplets = append(triplets, [3]float64{values[i], values[i+1], values[i+2]})
+	}
+	return triplets, nil
+}
+
+// levees reads the left and right levee station and elevation from a "Levee=" line, where a levee
+// is present when its flag is -1
+func levees(data string) ([2][]float64, error) {
+	result := [2][]float64{}
+	fields := strings.Split(data, ",")
+	for side := 0; side < 2; side++ {
+		i := side * 3
+		if i+2 >= len(fields) || strings.TrimSpace(fields[i]) != "-1" {
+			continue
+		}
+		station, err := stringtoFloat(fields[i+1])
+		if err != nil {
+			return result, err
+		}
+		elevation, err := stringtoFloat(fields[i+2])
+		if err != nil {
+			return result, err
+		}
+		result[side] = []float64{station, elevation}
+	}
+	return result, nil
+}
+
+// xsHeader reads the river station and the left overbank, channel and right overbank reach lengths
+// from the fields of a "Type RM Length L Ch R =" line
+func xsHeader(data []string) (xsGeometry, error) {
+	xs := xsGeometry{Name: strings.TrimSpace(data[1])}
+
+	rs, err := toNumeric(data[1])
+	if err != nil {
+		return xs, err
+	}
+	xs.Station, err = strconv.ParseFloat(rs, 64)
+	if err != nil {
+		return xs, err
+	}
+
+	for i := 0; i < 3 && i+2 < len(data); i++ {
+		length, err := stringtoFloat(data[i+2])
+		if err != nil {
+			return xs, err
+		}
+		xs.Lengths[i] = length
+	}
+	return xs, nil
+}
+
+// readStorageAreaGeometry reads the storage area and 2D flow area perimeters of a geometry file
+func readStorageAreaGeometry(fs filestore.FileStore, fn string) ([]storageAreaGeometry, error) {
+	storageAreas := []storageAreaGeometry{}
+
+	f, err := fs.GetObject(fn)
+	if err != nil {
+		return storageAreas, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+
+	var sa *storageAreaGeometry
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "Storage Area="):
+			storageAreas = append(storageAreas, storageAreaGeometry{Name: strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0])})
+			sa = &storageAreas[len(storageAreas)-1]
+
+		case strings.HasPrefix(line, "River Reach="):
+			sa = nil
+
+		case sa != nil && strings.HasPrefix(line, "Storage Area Surface Line="):
+			n, err := nDataPairs(line)
+			if err != nil {
+				return storageAreas, err
+			}
+			if n > 0 {
+				sa.Perimeter, err = dataPairsfromTextBlock(sc, n, 32, 16)
+				if err != nil {
+					return storageAreas, err
+				}
+			}
+
+		case sa != nil && strings.HasPrefix(line, "Storage Area Is2D="):
+			sa.Is2D = rightofEquals(line) != "0"
+		}
+	}
+	return storageAreas, nil
+}